@@ -0,0 +1,56 @@
+package connector
+
+import (
+	"sync"
+	"time"
+)
+
+// cacheTTL is how long values read once per organization or project are reused across pages before they are read again.
+const cacheTTL = 10 * time.Minute
+
+// keyedCache holds values that are expensive to read, such as the data of a whole project, keyed by organization or
+// project ID. A value is loaded on first use and reused until it expires or is invalidated; errors are not cached.
+type keyedCache[T any] struct {
+	mtx     sync.Mutex
+	ttl     time.Duration
+	entries map[string]keyedCacheEntry[T]
+}
+
+type keyedCacheEntry[T any] struct {
+	value     T
+	expiresAt time.Time
+}
+
+func newKeyedCache[T any](ttl time.Duration) *keyedCache[T] {
+	return &keyedCache[T]{
+		ttl:     ttl,
+		entries: make(map[string]keyedCacheEntry[T]),
+	}
+}
+
+// get returns the cached value for key, calling load when there is none or it has expired.
+func (c *keyedCache[T]) get(key string, load func() (T, error)) (T, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if entry, ok := c.entries[key]; ok && time.Now().Before(entry.expiresAt) {
+		return entry.value, nil
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	c.entries[key] = keyedCacheEntry[T]{value: value, expiresAt: time.Now().Add(c.ttl)}
+
+	return value, nil
+}
+
+// invalidate drops the cached value for key, so that the next get reads it again.
+func (c *keyedCache[T]) invalidate(key string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	delete(c.entries, key)
+}
@@ -0,0 +1,67 @@
+package connector
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyedCache(t *testing.T) {
+	loads := 0
+	load := func() (int, error) {
+		loads++
+		return loads, nil
+	}
+
+	t.Run("value is reused", func(t *testing.T) {
+		loads = 0
+		cache := newKeyedCache[int](time.Hour)
+
+		for range 3 {
+			value, err := cache.get("project", load)
+			require.NoError(t, err)
+			assert.Equal(t, 1, value)
+		}
+
+		value, err := cache.get("other-project", load)
+		require.NoError(t, err)
+		assert.Equal(t, 2, value)
+	})
+
+	t.Run("expired value is loaded again", func(t *testing.T) {
+		loads = 0
+		cache := newKeyedCache[int](0)
+
+		_, err := cache.get("project", load)
+		require.NoError(t, err)
+		value, err := cache.get("project", load)
+		require.NoError(t, err)
+		assert.Equal(t, 2, value)
+	})
+
+	t.Run("invalidated value is loaded again", func(t *testing.T) {
+		loads = 0
+		cache := newKeyedCache[int](time.Hour)
+
+		_, err := cache.get("project", load)
+		require.NoError(t, err)
+		cache.invalidate("project")
+		value, err := cache.get("project", load)
+		require.NoError(t, err)
+		assert.Equal(t, 2, value)
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		loads = 0
+		cache := newKeyedCache[int](time.Hour)
+
+		_, err := cache.get("project", func() (int, error) { return 0, errors.New("unavailable") })
+		require.Error(t, err)
+		value, err := cache.get("project", load)
+		require.NoError(t, err)
+		assert.Equal(t, 1, value)
+	})
+}
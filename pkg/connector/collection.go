@@ -3,6 +3,7 @@ package connector
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
@@ -11,31 +12,66 @@ import (
 
 	"github.com/conductorone/baton-mongodb-atlas/pkg/connector/mongodriver"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	"github.com/conductorone/baton-sdk/pkg/types/grant"
 	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
 	"go.mongodb.org/atlas-sdk/v20250312006/admin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+const userScopeTypeCluster = "CLUSTER"
+
+// Built-in roles that can be scoped to a single collection.
+var collectionRoles = []string{
+	roleRead,
+	"readWrite",
+}
+
 type collectionBuilder struct {
-	client      *admin.APIClient
-	mongodriver *mongodriver.MongoDriver
+	client                         *admin.APIClient
+	enableMongoDriver              bool
+	mongodriver                    *mongodriver.MongoDriver
+	deleteDatabaseUserWithReadOnly bool
+	// collectionRoleUsers holds the database users with collection-scoped roles of each project, keyed by project ID,
+	// so that they are read once per project rather than once per database.
+	collectionRoleUsers *keyedCache[[]admin.CloudDatabaseUser]
 }
 
 func (o *collectionBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
 	return collectionResourceType
 }
 
-func newCollectionBuilder(client *admin.APIClient, mongodriver *mongodriver.MongoDriver) *collectionBuilder {
+func newCollectionBuilder(
+	client *admin.APIClient,
+	enableMongoDriver bool,
+	mongodriver *mongodriver.MongoDriver,
+	deleteDatabaseUserWithReadOnly bool,
+) *collectionBuilder {
 	return &collectionBuilder{
-		client:      client,
-		mongodriver: mongodriver,
+		client:                         client,
+		enableMongoDriver:              enableMongoDriver,
+		mongodriver:                    mongodriver,
+		deleteDatabaseUserWithReadOnly: deleteDatabaseUserWithReadOnly,
+		collectionRoleUsers:            newKeyedCache[[]admin.CloudDatabaseUser](cacheTTL),
 	}
 }
 
-// List returns all the users from the database as resource objects.
-// Users include a UserTrait because they are the 'shape' of a standard user.
-func (o *collectionBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, opts rs.SyncOpAttrs) ([]*v2.Resource, *rs.SyncOpResults, error) {
-	l := ctxzap.Extract(ctx)
+// parseCollectionResourceId splits a collection resource ID into its project ID, cluster name, database name and
+// collection name.
+func parseCollectionResourceId(resourceId string) (string, string, string, string, error) {
+	splited := strings.SplitN(resourceId, "/", 4)
+	if len(splited) != 4 {
+		return "", "", "", "", fmt.Errorf("invalid resource ID: resource ID %s does not have expected format", resourceId)
+	}
+
+	return splited[0], splited[1], splited[2], splited[3], nil
+}
 
+// List returns the collections of a database. With the mongo driver enabled the collections are read from the
+// cluster; collections referenced by collection-scoped database user roles are always included.
+func (o *collectionBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, opts rs.SyncOpAttrs) ([]*v2.Resource, *rs.SyncOpResults, error) {
 	if parentResourceID == nil {
 		return nil, nil, nil
 	}
@@ -53,10 +89,46 @@ func (o *collectionBuilder) List(ctx context.Context, parentResourceID *v2.Resou
 	clusterName := splited[1]
 	dbName := splited[2]
 
+	collections, err := o.listRoleCollections(ctx, groupID, clusterName, dbName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if o.enableMongoDriver {
+		names, err := o.listDriverCollections(ctx, groupID, clusterName, dbName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, name := range names {
+			if !slices.Contains(collections, name) {
+				collections = append(collections, name)
+			}
+		}
+	}
+
+	resources := make([]*v2.Resource, 0)
+
+	for _, collectionName := range collections {
+		resource, err := newCollectionResource(groupID, clusterName, dbName, collectionName, parentResourceID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create resource: %w", err)
+		}
+
+		resources = append(resources, resource)
+	}
+
+	return resources, nil, nil
+}
+
+// listDriverCollections reads the collection names of a database from the cluster.
+func (o *collectionBuilder) listDriverCollections(ctx context.Context, groupID, clusterName, dbName string) ([]string, error) {
+	l := ctxzap.Extract(ctx)
+
 	_, client, err := o.mongodriver.Connect(ctx, groupID, clusterName)
 	if err != nil {
 		l.Error("failed to connect to MongoDB Atlas cluster", zap.String("group_id", groupID), zap.String("cluster_name", clusterName), zap.Error(err))
-		return nil, nil, fmt.Errorf("failed to connect to MongoDB Atlas cluster: %w", err)
+		return nil, fmt.Errorf("failed to connect to MongoDB Atlas cluster: %w", err)
 	}
 
 	db := client.Database(dbName, nil)
@@ -66,24 +138,91 @@ func (o *collectionBuilder) List(ctx context.Context, parentResourceID *v2.Resou
 		if strings.Contains(err.Error(), "(Unauthorized) not authorized") {
 			l.Info("unauthorized to list collections skipping", zap.String("group_id", groupID), zap.String("cluster_name", clusterName), zap.String("db_name", dbName))
 
-			return nil, nil, nil
+			return nil, nil
 		}
 
-		return nil, nil, fmt.Errorf("failed to list collection names: %w", err)
+		return nil, fmt.Errorf("failed to list collection names: %w", err)
 	}
 
-	resources := make([]*v2.Resource, 0)
+	return collections, nil
+}
 
-	for _, collectionName := range collections {
-		resource, err := newCollectionResource(groupID, clusterName, dbName, collectionName, parentResourceID)
+// listRoleCollections returns the collections of a database that database users hold collection-scoped roles on.
+func (o *collectionBuilder) listRoleCollections(ctx context.Context, groupID, clusterName, dbName string) ([]string, error) {
+	users, err := o.collectionRoleUsers.get(groupID, func() ([]admin.CloudDatabaseUser, error) {
+		return o.listCollectionRoleUsers(ctx, groupID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return roleCollections(users, clusterName, dbName), nil
+}
+
+// listCollectionRoleUsers returns the database users of a project that hold at least one collection-scoped role.
+func (o *collectionBuilder) listCollectionRoleUsers(ctx context.Context, groupID string) ([]admin.CloudDatabaseUser, error) {
+	var users []admin.CloudDatabaseUser
+
+	for page := 1; ; page++ {
+		dbUsers, resp, err := o.client.DatabaseUsersApi.ListDatabaseUsers(ctx, groupID).
+			PageNum(page).ItemsPerPage(resourcePageSize).
+			Execute() //nolint:bodyclose // The SDK handles closing the response body
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to create resource: %w", err)
+			return nil, fmt.Errorf("failed to list database users: %w", parseToUHttpError(resp, err))
 		}
 
-		resources = append(resources, resource)
+		for _, user := range dbUsers.GetResults() {
+			if slices.ContainsFunc(user.GetRoles(), func(r admin.DatabaseUserRole) bool { return r.GetCollectionName() != "" }) {
+				users = append(users, user)
+			}
+		}
+
+		if isLastPage(len(dbUsers.GetResults()), resourcePageSize) {
+			return users, nil
+		}
+	}
+}
+
+// roleCollections returns the collections of a database on a cluster that the database users hold
+// collection-scoped roles on, in the order they are first found.
+func roleCollections(users []admin.CloudDatabaseUser, clusterName, dbName string) []string {
+	var collections []string
+
+	for _, user := range users {
+		if !databaseUserHasClusterAccess(user, clusterName) {
+			continue
+		}
+
+		for _, role := range user.GetRoles() {
+			if role.DatabaseName != dbName || role.GetCollectionName() == "" {
+				continue
+			}
+
+			if !slices.Contains(collections, role.GetCollectionName()) {
+				collections = append(collections, role.GetCollectionName())
+			}
+		}
 	}
 
-	return resources, nil, nil
+	return collections
+}
+
+// databaseUserHasClusterAccess reports whether a database user's roles apply to a cluster. Users without cluster
+// scopes have access to every cluster of the project.
+func databaseUserHasClusterAccess(user admin.CloudDatabaseUser, clusterName string) bool {
+	hasClusterScope := false
+	for _, scope := range user.GetScopes() {
+		if scope.Type != userScopeTypeCluster {
+			continue
+		}
+
+		if scope.Name == clusterName {
+			return true
+		}
+		hasClusterScope = true
+	}
+
+	return !hasClusterScope
 }
 
 func newCollectionResource(
@@ -109,12 +248,165 @@ func newCollectionResource(
 	return resource, nil
 }
 
-// Entitlements always returns an empty slice for users.
+// Entitlements returns an entitlement for each role that can be scoped to the collection.
 func (o *collectionBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ rs.SyncOpAttrs) ([]*v2.Entitlement, *rs.SyncOpResults, error) {
-	return nil, nil, nil
+	ents := make([]*v2.Entitlement, 0)
+
+	for _, role := range collectionRoles {
+		ent := entitlement.NewAssignmentEntitlement(
+			resource,
+			role,
+			entitlement.WithGrantableTo(databaseUserResourceType),
+			entitlement.WithDisplayName(fmt.Sprintf("%s - %s", resource.DisplayName, role)),
+		)
+		ents = append(ents, ent)
+	}
+
+	return ents, nil, nil
 }
 
-// Grants always returns an empty slice for users since they don't have any entitlements.
+// Grants returns a grant for every collection-scoped role database users hold on the collection.
 func (o *collectionBuilder) Grants(ctx context.Context, resource *v2.Resource, opts rs.SyncOpAttrs) ([]*v2.Grant, *rs.SyncOpResults, error) {
-	return nil, nil, nil
+	groupID, clusterName, dbName, collectionName, err := parseCollectionResourceId(resource.Id.Resource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bag, page, err := parsePageToken(opts.PageToken.Token, &v2.ResourceId{ResourceType: collectionResourceType.Id})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse page token: %w", err)
+	}
+
+	dbUsers, resp, err := o.client.DatabaseUsersApi.ListDatabaseUsers(ctx, groupID).
+		PageNum(page).ItemsPerPage(resourcePageSize).
+		Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list database users: %w", parseToUHttpError(resp, err))
+	}
+
+	var grants []*v2.Grant
+	for _, user := range dbUsers.GetResults() {
+		if !databaseUserHasClusterAccess(user, clusterName) {
+			continue
+		}
+
+		userId := &v2.ResourceId{
+			ResourceType: databaseUserResourceType.Id,
+			Resource:     user.Username,
+		}
+
+		for _, role := range user.GetRoles() {
+			if role.DatabaseName != dbName || role.GetCollectionName() != collectionName {
+				continue
+			}
+
+			if !slices.Contains(collectionRoles, role.RoleName) {
+				continue
+			}
+
+			grants = append(grants, grant.NewGrant(resource, role.RoleName, userId))
+		}
+	}
+
+	if isLastPage(len(dbUsers.GetResults()), resourcePageSize) {
+		return grants, nil, nil
+	}
+
+	nextPage, err := getPageTokenFromPage(bag, page+1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate page token: %w", err)
+	}
+
+	return grants, &rs.SyncOpResults{NextPageToken: nextPage}, nil
+}
+
+func (o *collectionBuilder) Grant(ctx context.Context, resource *v2.Resource, entitlement *v2.Entitlement) ([]*v2.Grant, annotations.Annotations, error) {
+	if resource.Id.ResourceType != databaseUserResourceType.Id {
+		return nil, nil, fmt.Errorf("invalid resource type: expected %s, got %s", databaseUserResourceType.Id, resource.Id.ResourceType)
+	}
+
+	groupID, _, dbName, collectionName, err := parseCollectionResourceId(entitlement.Resource.Id.Resource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	role := entitlement.Slug
+	if !slices.Contains(collectionRoles, role) {
+		return nil, nil, fmt.Errorf("unknown entitlement: entitlement %s is not recognized", role)
+	}
+
+	dbUser, err := getDatabaseUser(ctx, o.client, groupID, resource.Id.Resource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, r := range dbUser.GetRoles() {
+		if isCollectionRole(r, dbName, collectionName, role) {
+			return nil, annotations.New(&v2.GrantAlreadyExists{}), nil
+		}
+	}
+
+	newRoles := append(dbUser.GetRoles(), admin.DatabaseUserRole{
+		DatabaseName:   dbName,
+		CollectionName: admin.PtrString(collectionName),
+		RoleName:       role,
+	})
+
+	dbUser.Roles = &newRoles
+
+	_, resp, err := o.client.DatabaseUsersApi.UpdateDatabaseUser(ctx, groupID, dbUser.DatabaseName, dbUser.Username, dbUser).
+		Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update database user: %w", parseToUHttpError(resp, err))
+	}
+	o.collectionRoleUsers.invalidate(groupID)
+
+	userId := &v2.ResourceId{
+		ResourceType: databaseUserResourceType.Id,
+		Resource:     dbUser.Username,
+	}
+
+	return []*v2.Grant{
+		grant.NewGrant(entitlement.Resource, role, userId),
+	}, nil, nil
+}
+
+func (o *collectionBuilder) Revoke(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
+	if grant.Principal.Id.ResourceType != databaseUserResourceType.Id {
+		return nil, fmt.Errorf("invalid resource type: expected %s, got %s", databaseUserResourceType.Id, grant.Principal.Id.ResourceType)
+	}
+
+	groupID, _, dbName, collectionName, err := parseCollectionResourceId(grant.Entitlement.Resource.Id.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	role := grant.Entitlement.Slug
+
+	dbUser, err := getDatabaseUser(ctx, o.client, groupID, grant.Principal.Id.Resource)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+		}
+		return nil, err
+	}
+
+	newRoles, found := removeDatabaseUserRoles(dbUser.GetRoles(), func(r admin.DatabaseUserRole) bool {
+		return isCollectionRole(r, dbName, collectionName, role)
+	})
+	if !found {
+		return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+	}
+
+	if err := saveDatabaseUserRoles(ctx, o.client, groupID, dbUser, newRoles, o.deleteDatabaseUserWithReadOnly); err != nil {
+		return nil, err
+	}
+	o.collectionRoleUsers.invalidate(groupID)
+
+	return nil, nil
+}
+
+// isCollectionRole reports whether a database user role is the given role scoped to the collection.
+func isCollectionRole(r admin.DatabaseUserRole, dbName, collectionName, role string) bool {
+	return r.DatabaseName == dbName && r.GetCollectionName() == collectionName && r.RoleName == role
 }
@@ -0,0 +1,137 @@
+package connector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/atlas-sdk/v20250312006/admin"
+)
+
+func TestCollections(t *testing.T) {
+	clusterScope := func(names ...string) *[]admin.UserScope {
+		var scopes []admin.UserScope
+		for _, name := range names {
+			scopes = append(scopes, admin.UserScope{Name: name, Type: userScopeTypeCluster})
+		}
+		return &scopes
+	}
+	collectionRole := func(dbName, collectionName, role string) admin.DatabaseUserRole {
+		return admin.DatabaseUserRole{DatabaseName: dbName, CollectionName: admin.PtrString(collectionName), RoleName: role}
+	}
+
+	t.Run("parseCollectionResourceId", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			resourceId string
+			expected   []string
+			wantErr    bool
+		}{
+			{
+				name:       "collection",
+				resourceId: "project/cluster/sales/orders",
+				expected:   []string{"project", "cluster", "sales", "orders"},
+			},
+			{
+				name:       "collection name with a slash",
+				resourceId: "project/cluster/sales/orders/2025",
+				expected:   []string{"project", "cluster", "sales", "orders/2025"},
+			},
+			{
+				name:       "database resource ID",
+				resourceId: "project/cluster/sales",
+				wantErr:    true,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				groupID, clusterName, dbName, collectionName, err := parseCollectionResourceId(tt.resourceId)
+				if tt.wantErr {
+					assert.Error(t, err)
+					return
+				}
+
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, []string{groupID, clusterName, dbName, collectionName})
+			})
+		}
+	})
+
+	t.Run("databaseUserHasClusterAccess", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			user     admin.CloudDatabaseUser
+			expected bool
+		}{
+			{name: "no scopes", user: admin.CloudDatabaseUser{}, expected: true},
+			{name: "scoped to the cluster", user: admin.CloudDatabaseUser{Scopes: clusterScope("other", "cluster")}, expected: true},
+			{name: "scoped to other clusters", user: admin.CloudDatabaseUser{Scopes: clusterScope("other")}, expected: false},
+			{
+				name: "only scoped to a data lake",
+				user: admin.CloudDatabaseUser{
+					Scopes: &[]admin.UserScope{{Name: "lake", Type: "DATA_LAKE"}},
+				},
+				expected: true,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expected, databaseUserHasClusterAccess(tt.user, "cluster"))
+			})
+		}
+	})
+
+	t.Run("roleCollections", func(t *testing.T) {
+		users := []admin.CloudDatabaseUser{
+			{
+				Username: "app",
+				Roles: &[]admin.DatabaseUserRole{
+					collectionRole("sales", "orders", roleRead),
+					collectionRole("sales", "customers", "readWrite"),
+					collectionRole("hr", "employees", roleRead),
+					{DatabaseName: "sales", RoleName: "readWrite"},
+				},
+			},
+			{
+				Username: "reporting",
+				Scopes:   clusterScope("cluster"),
+				Roles: &[]admin.DatabaseUserRole{
+					collectionRole("sales", "orders", "readWrite"),
+					collectionRole("sales", "invoices", roleRead),
+				},
+			},
+			{
+				Username: "archive",
+				Scopes:   clusterScope("archive"),
+				Roles: &[]admin.DatabaseUserRole{
+					collectionRole("sales", "archived_orders", roleRead),
+				},
+			},
+		}
+
+		tests := []struct {
+			name        string
+			clusterName string
+			dbName      string
+			expected    []string
+		}{
+			{name: "database on a shared cluster", clusterName: "cluster", dbName: "sales", expected: []string{"orders", "customers", "invoices"}},
+			{name: "database on a scoped cluster", clusterName: "archive", dbName: "sales", expected: []string{"orders", "customers", "archived_orders"}},
+			{name: "database without collection roles", clusterName: "cluster", dbName: "inventory"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expected, roleCollections(users, tt.clusterName, tt.dbName))
+			})
+		}
+	})
+
+	t.Run("isCollectionRole", func(t *testing.T) {
+		assert.True(t, isCollectionRole(collectionRole("sales", "orders", roleRead), "sales", "orders", roleRead))
+		assert.False(t, isCollectionRole(collectionRole("sales", "orders", "readWrite"), "sales", "orders", roleRead))
+		assert.False(t, isCollectionRole(collectionRole("sales", "invoices", roleRead), "sales", "orders", roleRead))
+		assert.False(t, isCollectionRole(admin.DatabaseUserRole{DatabaseName: "sales", RoleName: roleRead}, "sales", "orders", roleRead))
+	})
+}
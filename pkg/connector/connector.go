@@ -34,22 +34,29 @@ type MongoDB struct {
 
 // ResourceSyncers returns a ResourceSyncer for each resource type that should be synced from the upstream service.
 func (d *MongoDB) ResourceSyncers(_ context.Context) []connectorbuilder.ResourceSyncerV2 {
+	roleMappings := newRoleMappingCache(d.client)
+
 	builders := []connectorbuilder.ResourceSyncerV2{
-		newOrganizationBuilder(d.client),
+		newOrganizationBuilder(d.client, roleMappings),
 		newUserBuilder(d.client, d.createInviteKey),
 		newTeamBuilder(d.client),
-		newProjectBuilder(d.client),
+		newProjectBuilder(d.client, d.deleteDatabaseUserWithReadOnly, roleMappings),
 		newDatabaseUserBuilder(d.client),
 		newMongoClusterBuilder(d.client, d.enableSyncDatabases),
 		newOrgApiKeyBuilder(d.client),
+		newProjectApiKeyBuilder(d.client),
+		newCustomDatabaseRoleBuilder(d.client, d.deleteDatabaseUserWithReadOnly),
+		newServiceAccountBuilder(d.client),
+		newServiceAccountSecretBuilder(d.client),
+		newRoleMappingBuilder(d.client, roleMappings),
+		newIpAccessEntryBuilder(d.client),
 	}
 
 	if d.enableSyncDatabases {
-		builders = append(builders, newDatabaseBuilder(d.client, d.enableMongoDriver, d.mongodriver, d.deleteDatabaseUserWithReadOnly))
-
-		if d.enableMongoDriver {
-			builders = append(builders, newCollectionBuilder(d.client, d.mongodriver))
-		}
+		builders = append(builders,
+			newDatabaseBuilder(d.client, d.enableMongoDriver, d.mongodriver, d.deleteDatabaseUserWithReadOnly),
+			newCollectionBuilder(d.client, d.enableMongoDriver, d.mongodriver, d.deleteDatabaseUserWithReadOnly),
+		)
 	}
 
 	return builders
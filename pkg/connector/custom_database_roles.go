@@ -0,0 +1,407 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	"github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/conductorone/baton-sdk/pkg/uhttp"
+	"go.mongodb.org/atlas-sdk/v20250312006/admin"
+)
+
+// customDatabaseRoleClusterResource is the resource suffix used for cluster-wide privilege actions,
+// e.g. "INPROG@cluster", as opposed to "FIND@db" or "FIND@db.collection".
+const customDatabaseRoleClusterResource = "cluster"
+
+type customDatabaseRoleBuilder struct {
+	resourceType                   *v2.ResourceType
+	client                         *admin.APIClient
+	deleteDatabaseUserWithReadOnly bool
+}
+
+var _ connectorbuilder.ResourceManagerV2 = (*customDatabaseRoleBuilder)(nil)
+
+func (o *customDatabaseRoleBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return customDatabaseRoleResourceType
+}
+
+// parseCustomDatabaseRoleResourceId splits a custom role resource ID into its project ID and role name.
+func parseCustomDatabaseRoleResourceId(resourceId string) (string, string, error) {
+	parts := strings.SplitN(resourceId, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid custom database role resource id: %s", resourceId)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// parseCustomDatabaseRoleActions parses a comma-separated list of privilege actions in the same
+// format used by the synced role profile ("ACTION@cluster", "ACTION@db" or "ACTION@db.collection").
+func parseCustomDatabaseRoleActions(value string) ([]admin.DatabasePrivilegeAction, error) {
+	var rv []admin.DatabasePrivilegeAction
+	index := make(map[string]int)
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		action, target, ok := strings.Cut(item, "@")
+		if !ok || action == "" || target == "" {
+			return nil, fmt.Errorf("invalid custom database role action %q: expected ACTION@resource", item)
+		}
+
+		var resource admin.DatabasePermittedNamespaceResource
+		if target == customDatabaseRoleClusterResource {
+			resource.Cluster = true
+		} else {
+			db, collection, _ := strings.Cut(target, ".")
+			if db == "" {
+				return nil, fmt.Errorf("invalid custom database role action %q: database name is empty", item)
+			}
+			resource.Db = db
+			resource.Collection = collection
+		}
+
+		i, ok := index[action]
+		if !ok {
+			rv = append(rv, admin.DatabasePrivilegeAction{
+				Action:    action,
+				Resources: &[]admin.DatabasePermittedNamespaceResource{},
+			})
+			i = len(rv) - 1
+			index[action] = i
+		}
+
+		resources := append(*rv[i].Resources, resource)
+		rv[i].Resources = &resources
+	}
+
+	return rv, nil
+}
+
+// parseCustomDatabaseRoleInheritedRoles parses a comma-separated list of "role@db" entries.
+func parseCustomDatabaseRoleInheritedRoles(value string) ([]admin.DatabaseInheritedRole, error) {
+	var rv []admin.DatabaseInheritedRole
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		role, db, ok := strings.Cut(item, "@")
+		if !ok || role == "" || db == "" {
+			return nil, fmt.Errorf("invalid inherited role %q: expected role@db", item)
+		}
+
+		rv = append(rv, admin.DatabaseInheritedRole{
+			Role: role,
+			Db:   db,
+		})
+	}
+
+	return rv, nil
+}
+
+func newCustomDatabaseRoleResource(projectId *v2.ResourceId, role admin.UserCustomDBRole) (*v2.Resource, error) {
+	var actions []string
+	for _, action := range role.GetActions() {
+		for _, r := range action.GetResources() {
+			switch {
+			case r.Cluster:
+				actions = append(actions, fmt.Sprintf("%s@%s", action.Action, customDatabaseRoleClusterResource))
+			case r.Collection != "":
+				actions = append(actions, fmt.Sprintf("%s@%s.%s", action.Action, r.Db, r.Collection))
+			default:
+				actions = append(actions, fmt.Sprintf("%s@%s", action.Action, r.Db))
+			}
+		}
+	}
+
+	var inheritedRoles []string
+	for _, inherited := range role.GetInheritedRoles() {
+		inheritedRoles = append(inheritedRoles, fmt.Sprintf("%s@%s", inherited.Role, inherited.Db))
+	}
+
+	profile := map[string]interface{}{
+		"role_name":       role.RoleName,
+		"group_id":        projectId.Resource,
+		"actions":         strings.Join(actions, ", "),
+		"inherited_roles": strings.Join(inheritedRoles, ", "),
+	}
+
+	roleTraits := []rs.RoleTraitOption{
+		rs.WithRoleProfile(profile),
+	}
+
+	id := fmt.Sprintf("%s/%s", projectId.Resource, role.RoleName)
+
+	resource, err := rs.NewRoleResource(
+		role.RoleName,
+		customDatabaseRoleResourceType,
+		id,
+		roleTraits,
+		rs.WithParentResourceID(projectId),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+func newCustomDatabaseRoleBuilder(client *admin.APIClient, deleteDatabaseUserWithReadOnly bool) *customDatabaseRoleBuilder {
+	return &customDatabaseRoleBuilder{
+		resourceType:                   customDatabaseRoleResourceType,
+		client:                         client,
+		deleteDatabaseUserWithReadOnly: deleteDatabaseUserWithReadOnly,
+	}
+}
+
+// List returns the project's custom database roles. The Atlas API returns them all in a single response.
+func (o *customDatabaseRoleBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, _ rs.SyncOpAttrs) ([]*v2.Resource, *rs.SyncOpResults, error) {
+	if parentResourceID == nil {
+		return nil, nil, nil
+	}
+
+	if parentResourceID.ResourceType != projectResourceType.Id {
+		return nil, nil, fmt.Errorf("invalid parent resource type: expected %s, got %s", projectResourceType.Id, parentResourceID.ResourceType)
+	}
+
+	roles, resp, err := o.client.CustomDatabaseRolesApi.ListCustomDatabaseRoles(
+		ctx,
+		parentResourceID.GetResource(),
+	).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list custom database roles: %w", parseToUHttpError(resp, err))
+	}
+
+	var resources []*v2.Resource
+	for _, role := range roles {
+		resource, err := newCustomDatabaseRoleResource(parentResourceID, role)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create custom database role resource: %w", err)
+		}
+
+		resources = append(resources, resource)
+	}
+
+	return resources, nil, nil
+}
+
+func (o *customDatabaseRoleBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ rs.SyncOpAttrs) ([]*v2.Entitlement, *rs.SyncOpResults, error) {
+	assigmentOptions := []ent.EntitlementOption{
+		ent.WithGrantableTo(databaseUserResourceType),
+		ent.WithDescription(fmt.Sprintf("Assigned the %s custom database role", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s role %s", resource.DisplayName, assignedEntitlement)),
+	}
+
+	return []*v2.Entitlement{
+		ent.NewAssignmentEntitlement(resource, assignedEntitlement, assigmentOptions...),
+	}, nil, nil
+}
+
+// Grants returns a grant for every database user in the project that holds the custom role.
+func (o *customDatabaseRoleBuilder) Grants(ctx context.Context, resource *v2.Resource, opts rs.SyncOpAttrs) ([]*v2.Grant, *rs.SyncOpResults, error) {
+	groupId, roleName, err := parseCustomDatabaseRoleResourceId(resource.Id.Resource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bag, page, err := parsePageToken(opts.PageToken.Token, &v2.ResourceId{ResourceType: o.resourceType.Id})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dbUsers, resp, err := o.client.DatabaseUsersApi.ListDatabaseUsers(ctx, groupId).
+		IncludeCount(true).PageNum(page).ItemsPerPage(resourcePageSize).
+		Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list database users: %w", parseToUHttpError(resp, err))
+	}
+
+	var rv []*v2.Grant
+	for _, user := range dbUsers.GetResults() {
+		for _, role := range user.GetRoles() {
+			if role.RoleName != roleName {
+				continue
+			}
+
+			userId := &v2.ResourceId{
+				ResourceType: databaseUserResourceType.Id,
+				Resource:     user.Username,
+			}
+
+			rv = append(rv, grant.NewGrant(resource, assignedEntitlement, userId))
+			break
+		}
+	}
+
+	if isLastPage(len(dbUsers.GetResults()), resourcePageSize) {
+		return rv, nil, nil
+	}
+
+	nextPage, err := getPageTokenFromPage(bag, page+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rv, &rs.SyncOpResults{NextPageToken: nextPage}, nil
+}
+
+// Grant adds the custom role to the database user's role list.
+func (o *customDatabaseRoleBuilder) Grant(ctx context.Context, resource *v2.Resource, entitlement *v2.Entitlement) ([]*v2.Grant, annotations.Annotations, error) {
+	if resource.Id.ResourceType != databaseUserResourceType.Id {
+		return nil, nil, fmt.Errorf("invalid resource type: expected %s, got %s", databaseUserResourceType.Id, resource.Id.ResourceType)
+	}
+
+	groupID, roleName, err := parseCustomDatabaseRoleResourceId(entitlement.Resource.Id.Resource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dbUser, err := getDatabaseUser(ctx, o.client, groupID, resource.Id.Resource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, r := range dbUser.GetRoles() {
+		if r.RoleName == roleName {
+			return nil, annotations.New(&v2.GrantAlreadyExists{}), nil
+		}
+	}
+
+	// Custom roles are always defined on, and assigned against, the admin database.
+	newRoles := append(dbUser.GetRoles(), admin.DatabaseUserRole{
+		DatabaseName: databaseNameAdmin,
+		RoleName:     roleName,
+	})
+
+	dbUser.Roles = &newRoles
+
+	_, resp, err := o.client.DatabaseUsersApi.UpdateDatabaseUser(ctx, groupID, dbUser.DatabaseName, dbUser.Username, dbUser).
+		Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update database user: %w", parseToUHttpError(resp, err))
+	}
+
+	userId := &v2.ResourceId{
+		ResourceType: databaseUserResourceType.Id,
+		Resource:     dbUser.Username,
+	}
+
+	return []*v2.Grant{
+		grant.NewGrant(entitlement.Resource, assignedEntitlement, userId),
+	}, nil, nil
+}
+
+// Revoke removes the custom role from the database user, deleting the user when no meaningful roles remain.
+func (o *customDatabaseRoleBuilder) Revoke(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
+	if grant.Principal.Id.ResourceType != databaseUserResourceType.Id {
+		return nil, fmt.Errorf("invalid resource type: expected %s, got %s", databaseUserResourceType.Id, grant.Principal.Id.ResourceType)
+	}
+
+	groupID, roleName, err := parseCustomDatabaseRoleResourceId(grant.Entitlement.Resource.Id.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	dbUser, err := getDatabaseUser(ctx, o.client, groupID, grant.Principal.Id.Resource)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+		}
+		return nil, err
+	}
+
+	newRoles, found := removeDatabaseUserRoles(dbUser.GetRoles(), func(r admin.DatabaseUserRole) bool {
+		return r.RoleName == roleName
+	})
+	if !found {
+		return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+	}
+
+	if err := saveDatabaseUserRoles(ctx, o.client, groupID, dbUser, newRoles, o.deleteDatabaseUserWithReadOnly); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// Create creates a custom database role in the parent project. The role name defaults to the
+// resource display name, and the actions and inherited roles are read from the role profile
+// using the same format that sync emits.
+func (o *customDatabaseRoleBuilder) Create(ctx context.Context, resource *v2.Resource) (*v2.Resource, annotations.Annotations, error) {
+	parentId := resource.GetParentResourceId()
+	if parentId == nil || parentId.ResourceType != projectResourceType.Id {
+		return nil, nil, uhttp.WrapErrors(codes.InvalidArgument, "mongo-db-connector: custom database role must have a project parent", fmt.Errorf("parent resource is missing or not a project"))
+	}
+
+	profile := rs.GetProfile(resource)
+
+	roleName, ok := rs.GetProfileStringValue(profile, "role_name")
+	if !ok || roleName == "" {
+		roleName = resource.DisplayName
+	}
+	if roleName == "" {
+		return nil, nil, uhttp.WrapErrors(codes.InvalidArgument, "mongo-db-connector: role name is required", fmt.Errorf("role_name and display name are empty"))
+	}
+
+	rawActions, _ := rs.GetProfileStringValue(profile, "actions")
+	actions, err := parseCustomDatabaseRoleActions(rawActions)
+	if err != nil {
+		return nil, nil, uhttp.WrapErrors(codes.InvalidArgument, "mongo-db-connector: invalid actions", err)
+	}
+
+	rawInheritedRoles, _ := rs.GetProfileStringValue(profile, "inherited_roles")
+	inheritedRoles, err := parseCustomDatabaseRoleInheritedRoles(rawInheritedRoles)
+	if err != nil {
+		return nil, nil, uhttp.WrapErrors(codes.InvalidArgument, "mongo-db-connector: invalid inherited roles", err)
+	}
+
+	role, resp, err := o.client.CustomDatabaseRolesApi.CreateCustomDatabaseRole(
+		ctx,
+		parentId.Resource,
+		&admin.UserCustomDBRole{
+			RoleName:       roleName,
+			Actions:        &actions,
+			InheritedRoles: &inheritedRoles,
+		},
+	).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create custom database role: %w", parseToUHttpError(resp, err))
+	}
+
+	created, err := newCustomDatabaseRoleResource(parentId, *role)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create custom database role resource: %w", err)
+	}
+
+	return created, nil, nil
+}
+
+// Delete removes the custom database role from its project.
+func (o *customDatabaseRoleBuilder) Delete(ctx context.Context, resourceId *v2.ResourceId, _ *v2.ResourceId) (annotations.Annotations, error) {
+	groupId, roleName, err := parseCustomDatabaseRoleResourceId(resourceId.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.client.CustomDatabaseRolesApi.DeleteCustomDatabaseRole(ctx, groupId, roleName).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete custom database role: %w", parseToUHttpError(resp, err))
+	}
+
+	return nil, nil
+}
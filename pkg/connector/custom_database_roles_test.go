@@ -0,0 +1,57 @@
+package connector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/atlas-sdk/v20250312006/admin"
+)
+
+func TestCustomDatabaseRoles(t *testing.T) {
+	t.Run("parseCustomDatabaseRoleResourceId", func(t *testing.T) {
+		groupId, roleName, err := parseCustomDatabaseRoleResourceId("654be90ed21dc34308aba9bb/app-reader")
+		assert.NoError(t, err)
+		assert.Equal(t, "654be90ed21dc34308aba9bb", groupId)
+		assert.Equal(t, "app-reader", roleName)
+
+		_, _, err = parseCustomDatabaseRoleResourceId("654be90ed21dc34308aba9bb")
+		assert.Error(t, err)
+	})
+
+	t.Run("parseCustomDatabaseRoleActions", func(t *testing.T) {
+		actions, err := parseCustomDatabaseRoleActions("FIND@sales.orders, FIND@sales, INPROG@cluster,")
+		assert.NoError(t, err)
+		assert.Equal(t, []admin.DatabasePrivilegeAction{
+			{
+				Action: "FIND",
+				Resources: &[]admin.DatabasePermittedNamespaceResource{
+					{Db: "sales", Collection: "orders"},
+					{Db: "sales"},
+				},
+			},
+			{
+				Action: "INPROG",
+				Resources: &[]admin.DatabasePermittedNamespaceResource{
+					{Cluster: true},
+				},
+			},
+		}, actions)
+
+		for _, invalid := range []string{"FIND", "FIND@", "@sales", "FIND@.orders"} {
+			_, err := parseCustomDatabaseRoleActions(invalid)
+			assert.Error(t, err, invalid)
+		}
+	})
+
+	t.Run("parseCustomDatabaseRoleInheritedRoles", func(t *testing.T) {
+		roles, err := parseCustomDatabaseRoleInheritedRoles("read@sales, app-reader@admin")
+		assert.NoError(t, err)
+		assert.Equal(t, []admin.DatabaseInheritedRole{
+			{Role: "read", Db: "sales"},
+			{Role: "app-reader", Db: "admin"},
+		}, roles)
+
+		_, err = parseCustomDatabaseRoleInheritedRoles("read")
+		assert.Error(t, err)
+	})
+}
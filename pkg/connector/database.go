@@ -34,6 +34,18 @@ var dbRoles = []string{
 	"readWrite", // DB and collections
 }
 
+// Built-in roles that are granted on the admin database and apply to every database of the project's clusters.
+// https://www.mongodb.com/docs/atlas/mongodb-users-roles-and-privileges/#built-in-roles-and-privileges
+var clusterWideDatabaseRoles = []string{
+	"atlasAdmin",
+	"readWriteAnyDatabase",
+	"readAnyDatabase",
+	"dbAdminAnyDatabase",
+	"clusterMonitor",
+	"backup",
+	"enableSharding",
+}
+
 type databaseBuilder struct {
 	client                         *admin.APIClient
 	enableMongoDriver              bool
@@ -155,7 +167,7 @@ func (o *databaseBuilder) List(ctx context.Context, parentResourceID *v2.Resourc
 			continue
 		}
 
-		resource, err := newDatabaseResource(groupID, clusterName, database, parentResourceID, o.enableMongoDriver)
+		resource, err := newDatabaseResource(groupID, clusterName, database, parentResourceID)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to create resource: %w", err)
 		}
@@ -174,7 +186,7 @@ func (o *databaseBuilder) List(ctx context.Context, parentResourceID *v2.Resourc
 	return resources, &rs.SyncOpResults{NextPageToken: nextPage}, nil
 }
 
-func newDatabaseResource(groupID string, clusterName string, dbName string, parentId *v2.ResourceId, enableMongoDriver bool) (*v2.Resource, error) {
+func newDatabaseResource(groupID string, clusterName string, dbName string, parentId *v2.ResourceId) (*v2.Resource, error) {
 	profile := map[string]interface{}{
 		"db_name": dbName,
 	}
@@ -185,12 +197,9 @@ func newDatabaseResource(groupID string, clusterName string, dbName string, pare
 
 	rsOptions := []rs.ResourceOption{
 		rs.WithParentResourceID(parentId),
-	}
-
-	if enableMongoDriver {
-		rsOptions = append(rsOptions, rs.WithAnnotation(&v2.ChildResourceType{
+		rs.WithAnnotation(&v2.ChildResourceType{
 			ResourceTypeId: collectionResourceType.Id,
-		}))
+		}),
 	}
 
 	id := fmt.Sprintf("%s/%s/%s", groupID, clusterName, dbName)
@@ -304,7 +313,7 @@ func (o *databaseBuilder) Grant(ctx context.Context, resource *v2.Resource, enti
 	}
 
 	for _, r := range dbUser.GetRoles() {
-		if r.DatabaseName == dbName && r.RoleName == role {
+		if r.DatabaseName == dbName && !r.HasCollectionName() && r.RoleName == role {
 			return nil, annotations.New(&v2.GrantAlreadyExists{}), nil
 		}
 	}
@@ -363,7 +372,7 @@ func (o *databaseBuilder) Revoke(ctx context.Context, grant *v2.Grant) (annotati
 	found := false
 	var newRoles []admin.DatabaseUserRole
 	for _, r := range dbUser.GetRoles() {
-		if r.DatabaseName == dbName && r.RoleName == role {
+		if r.DatabaseName == dbName && !r.HasCollectionName() && r.RoleName == role {
 			found = true
 			continue // Skip the role we want to remove
 		}
@@ -393,12 +402,18 @@ func (o *databaseBuilder) Revoke(ctx context.Context, grant *v2.Grant) (annotati
 }
 
 func (o *databaseBuilder) shouldDeleteUser(roles []admin.DatabaseUserRole) bool {
+	return shouldDeleteDatabaseUser(roles, o.deleteDatabaseUserWithReadOnly)
+}
+
+// shouldDeleteDatabaseUser reports whether a database user left with the given roles should be deleted
+// instead of updated: either no roles remain, or only read@admin remains and deleteWithReadOnly is enabled.
+func shouldDeleteDatabaseUser(roles []admin.DatabaseUserRole, deleteWithReadOnly bool) bool {
 	if len(roles) == 0 {
 		return true
 	}
 
 	if len(roles) == 1 {
-		if !o.deleteDatabaseUserWithReadOnly {
+		if !deleteWithReadOnly {
 			return false
 		}
 
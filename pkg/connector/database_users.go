@@ -3,16 +3,23 @@ package connector
 import (
 	"context"
 	"fmt"
+	"time"
 
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/annotations"
 	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
 	"go.mongodb.org/atlas-sdk/v20250312006/admin"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type databaseUserBuilder struct {
 	resourceType *v2.ResourceType
 	client       *admin.APIClient
+	// activity holds the authentication activity of each project's database users, keyed by project ID.
+	activity *keyedCache[projectDatabaseUserActivity]
 }
 
 func (o *databaseUserBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
@@ -20,6 +27,17 @@ func (o *databaseUserBuilder) ResourceType(ctx context.Context) *v2.ResourceType
 }
 
 func newDatabaseUserResource(ctx context.Context, projectId *v2.ResourceId, user admin.CloudDatabaseUser) (*v2.Resource, error) {
+	return newDatabaseUserResourceWithActivity(ctx, projectId, user, nil)
+}
+
+// newDatabaseUserResourceWithActivity builds a database user resource, adding the authentication activity
+// read from the project's access logs when it is known.
+func newDatabaseUserResourceWithActivity(
+	ctx context.Context,
+	projectId *v2.ResourceId,
+	user admin.CloudDatabaseUser,
+	activity *databaseUserActivity,
+) (*v2.Resource, error) {
 	profile := map[string]interface{}{
 		"username":      user.Username,
 		"login":         user.Username,
@@ -27,13 +45,22 @@ func newDatabaseUserResource(ctx context.Context, projectId *v2.ResourceId, user
 	}
 
 	userTraits := []rs.UserTraitOption{
-		rs.WithUserProfile(profile),
 		rs.WithUserLogin(user.Username),
 		rs.WithStatus(v2.UserTrait_Status_STATUS_ENABLED), // The only possible state for this type of user.
 		// Atlas database users are programmatic DB auth credentials (machine identities), not humans.
 		rs.WithAccountType(v2.UserTrait_ACCOUNT_TYPE_SERVICE),
 	}
 
+	if activity != nil {
+		profile["failed_auth_count"] = activity.FailedAuthCount
+		if !activity.LastLogin.IsZero() {
+			profile["last_login"] = activity.LastLogin.Format(time.RFC3339)
+			userTraits = append(userTraits, rs.WithLastLogin(activity.LastLogin))
+		}
+	}
+
+	userTraits = append(userTraits, rs.WithUserProfile(profile))
+
 	resource, err := rs.NewUserResource(
 		user.Username,
 		databaseUserResourceType,
@@ -52,6 +79,7 @@ func newDatabaseUserBuilder(client *admin.APIClient) *databaseUserBuilder {
 	return &databaseUserBuilder{
 		resourceType: databaseUserResourceType,
 		client:       client,
+		activity:     newKeyedCache[projectDatabaseUserActivity](cacheTTL),
 	}
 }
 
@@ -77,9 +105,11 @@ func (o *databaseUserBuilder) List(ctx context.Context, parentResourceID *v2.Res
 		return nil, nil, nil
 	}
 
+	activity := o.getDatabaseUserActivity(ctx, parentResourceID.GetResource())
+
 	var resources []*v2.Resource
 	for _, user := range *users.Results {
-		resource, err := newDatabaseUserResource(ctx, parentResourceID, user)
+		resource, err := newDatabaseUserResourceWithActivity(ctx, parentResourceID, user, activity.of(user.Username))
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to create database user resource: %w", err)
 		}
@@ -99,6 +129,92 @@ func (o *databaseUserBuilder) List(ctx context.Context, parentResourceID *v2.Res
 	return resources, &rs.SyncOpResults{NextPageToken: nextPage}, nil
 }
 
+// Get returns a single database user, so that it can be re-synced when its project's activity feed reports a change.
+func (o *databaseUserBuilder) Get(ctx context.Context, resourceId *v2.ResourceId, parentResourceId *v2.ResourceId) (*v2.Resource, annotations.Annotations, error) {
+	if parentResourceId == nil {
+		return nil, nil, fmt.Errorf("database user must have a parent resource: parent resource ID is nil")
+	}
+
+	user, err := getDatabaseUser(ctx, o.client, parentResourceId.Resource, resourceId.Resource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	activity := o.getDatabaseUserActivity(ctx, parentResourceId.Resource)
+
+	resource, err := newDatabaseUserResourceWithActivity(ctx, parentResourceId, *user, activity.of(user.Username))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create database user resource: %w", err)
+	}
+
+	return resource, nil, nil
+}
+
+// getDatabaseUser returns a database user of a project. Password users authenticate against the admin database,
+// while certificate, IAM, LDAP and OIDC users authenticate against $external; the resource ID only holds the username,
+// so both are tried.
+func getDatabaseUser(ctx context.Context, client *admin.APIClient, groupId, username string) (*admin.CloudDatabaseUser, error) {
+	var err error
+	for _, databaseName := range []string{databaseNameAdmin, databaseNameExternal} {
+		user, resp, getErr := client.DatabaseUsersApi.GetDatabaseUser(ctx, groupId, databaseName, username).Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if getErr == nil {
+			return user, nil
+		}
+
+		err = parseToUHttpError(resp, getErr)
+		if status.Code(err) != codes.NotFound {
+			break
+		}
+	}
+
+	return nil, fmt.Errorf("failed to get database user: %w", err)
+}
+
+// removeDatabaseUserRoles returns the roles that do not match, and whether any role matched.
+func removeDatabaseUserRoles(roles []admin.DatabaseUserRole, match func(admin.DatabaseUserRole) bool) ([]admin.DatabaseUserRole, bool) {
+	found := false
+	var remaining []admin.DatabaseUserRole
+	for _, r := range roles {
+		if match(r) {
+			found = true
+			continue
+		}
+		remaining = append(remaining, r)
+	}
+
+	return remaining, found
+}
+
+// saveDatabaseUserRoles replaces the roles of a database user, deleting the user instead when no meaningful roles
+// remain (see shouldDeleteDatabaseUser).
+func saveDatabaseUserRoles(
+	ctx context.Context,
+	client *admin.APIClient,
+	groupId string,
+	dbUser *admin.CloudDatabaseUser,
+	roles []admin.DatabaseUserRole,
+	deleteWithReadOnly bool,
+) error {
+	if shouldDeleteDatabaseUser(roles, deleteWithReadOnly) {
+		resp, err := client.DatabaseUsersApi.DeleteDatabaseUser(ctx, groupId, dbUser.DatabaseName, dbUser.Username).
+			Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			return fmt.Errorf("failed to delete database user: %w", parseToUHttpError(resp, err))
+		}
+
+		return nil
+	}
+
+	dbUser.Roles = &roles
+	_, resp, err := client.DatabaseUsersApi.UpdateDatabaseUser(ctx, groupId, dbUser.DatabaseName, dbUser.Username, dbUser).
+		Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return fmt.Errorf("failed to update database user: %w", parseToUHttpError(resp, err))
+	}
+
+	return nil
+}
+
 // Entitlements always returns an empty slice for users.
 func (o *databaseUserBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ rs.SyncOpAttrs) ([]*v2.Entitlement, *rs.SyncOpResults, error) {
 	return nil, nil, nil
@@ -125,3 +241,99 @@ func (o *databaseUserBuilder) Delete(ctx context.Context, resourceId *v2.Resourc
 
 	return nil, nil
 }
+
+// accessLogTimestampLayout is the layout of the timestamps in Atlas access logs, for example "Mon Aug 05 14:00:00 GMT 2019".
+const accessLogTimestampLayout = "Mon Jan 02 15:04:05 MST 2006"
+
+// databaseUserActivity is the authentication activity of a database user across the clusters of a project.
+type databaseUserActivity struct {
+	LastLogin       time.Time
+	FailedAuthCount int
+}
+
+// projectDatabaseUserActivity is the authentication activity of a project's database users, keyed by username.
+// It is nil when the project's access logs could not be read.
+type projectDatabaseUserActivity map[string]databaseUserActivity
+
+// of returns the activity of a database user, or nil when the project's activity is unavailable.
+func (a projectDatabaseUserActivity) of(username string) *databaseUserActivity {
+	if a == nil {
+		return nil
+	}
+
+	userActivity := a[username]
+	return &userActivity
+}
+
+// getDatabaseUserActivity returns the authentication activity of the project's database users, reading the access
+// logs once per project and reusing them across pages. Activity that cannot be read is logged and reported as
+// unavailable, so that it does not fail the sync.
+func (o *databaseUserBuilder) getDatabaseUserActivity(ctx context.Context, groupId string) projectDatabaseUserActivity {
+	activity, _ := o.activity.get(groupId, func() (projectDatabaseUserActivity, error) {
+		activity, err := o.listDatabaseUserActivity(ctx, groupId)
+		if err != nil {
+			ctxzap.Extract(ctx).Warn(
+				"baton-mongodb-atlas: failed to read database user activity",
+				zap.String("groupId", groupId),
+				zap.Error(err),
+			)
+			return nil, nil
+		}
+		return activity, nil
+	})
+
+	return activity
+}
+
+// listDatabaseUserActivity reads the access logs of every cluster in the project and returns the last successful
+// authentication and the number of failed authentications of each database user, keyed by username.
+// Clusters whose access logs cannot be read, such as paused clusters, are skipped.
+func (o *databaseUserBuilder) listDatabaseUserActivity(ctx context.Context, groupId string) (map[string]databaseUserActivity, error) {
+	l := ctxzap.Extract(ctx)
+	activity := make(map[string]databaseUserActivity)
+
+	for page := 1; ; page++ {
+		clusters, resp, err := o.client.ClustersApi.ListClusters(ctx, groupId).PageNum(page).ItemsPerPage(resourcePageSize).Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			return nil, fmt.Errorf("failed to list clusters: %w", parseToUHttpError(resp, err))
+		}
+
+		for _, cluster := range clusters.GetResults() {
+			logs, resp, err := o.client.AccessTrackingApi.ListAccessLogsByClusterName(ctx, groupId, cluster.GetName()).Execute() //nolint:bodyclose // The SDK handles closing the response body
+			if err != nil {
+				l.Warn(
+					"baton-mongodb-atlas: failed to list cluster access logs",
+					zap.String("groupId", groupId),
+					zap.String("clusterName", cluster.GetName()),
+					zap.Error(parseToUHttpError(resp, err)),
+				)
+				continue
+			}
+
+			addAccessLogActivity(activity, logs.GetAccessLogs())
+		}
+
+		if isLastPage(len(clusters.GetResults()), resourcePageSize) {
+			return activity, nil
+		}
+	}
+}
+
+// addAccessLogActivity counts the failed authentications and records the last successful authentication of each
+// user in the access log entries. Entries without a username, and timestamps that cannot be parsed, are ignored.
+func addAccessLogActivity(activity map[string]databaseUserActivity, entries []admin.MongoDBAccessLogs) {
+	for _, entry := range entries {
+		username := entry.GetUsername()
+		if username == "" {
+			continue
+		}
+
+		userActivity := activity[username]
+		if !entry.GetAuthResult() {
+			userActivity.FailedAuthCount++
+		} else if timestamp, err := time.Parse(accessLogTimestampLayout, entry.GetTimestamp()); err == nil && timestamp.After(userActivity.LastLogin) {
+			userActivity.LastLogin = timestamp
+		}
+		activity[username] = userActivity
+	}
+}
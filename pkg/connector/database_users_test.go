@@ -0,0 +1,142 @@
+package connector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/atlas-sdk/v20250312006/admin"
+)
+
+func TestDatabaseUsers(t *testing.T) {
+	readAdmin := admin.DatabaseUserRole{DatabaseName: databaseNameAdmin, RoleName: roleRead}
+	appReader := admin.DatabaseUserRole{DatabaseName: databaseNameAdmin, RoleName: "app-reader"}
+	readWriteSales := admin.DatabaseUserRole{DatabaseName: "sales", RoleName: "readWrite"}
+	readOrders := admin.DatabaseUserRole{DatabaseName: "sales", CollectionName: admin.PtrString("orders"), RoleName: roleRead}
+
+	t.Run("removeDatabaseUserRoles", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			roles     []admin.DatabaseUserRole
+			roleName  string
+			remaining []admin.DatabaseUserRole
+			found     bool
+		}{
+			{
+				name:  "no roles",
+				found: false,
+			},
+			{
+				name:      "role not held",
+				roles:     []admin.DatabaseUserRole{readAdmin, readWriteSales},
+				roleName:  "app-reader",
+				remaining: []admin.DatabaseUserRole{readAdmin, readWriteSales},
+				found:     false,
+			},
+			{
+				name:      "role removed",
+				roles:     []admin.DatabaseUserRole{readAdmin, appReader, readWriteSales},
+				roleName:  "app-reader",
+				remaining: []admin.DatabaseUserRole{readAdmin, readWriteSales},
+				found:     true,
+			},
+			{
+				name:     "last role removed",
+				roles:    []admin.DatabaseUserRole{appReader},
+				roleName: "app-reader",
+				found:    true,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				remaining, found := removeDatabaseUserRoles(tt.roles, func(r admin.DatabaseUserRole) bool {
+					return r.RoleName == tt.roleName
+				})
+				assert.Equal(t, tt.remaining, remaining)
+				assert.Equal(t, tt.found, found)
+			})
+		}
+	})
+
+	t.Run("shouldDeleteDatabaseUser", func(t *testing.T) {
+		tests := []struct {
+			name               string
+			roles              []admin.DatabaseUserRole
+			deleteWithReadOnly bool
+			expected           bool
+		}{
+			{name: "no roles left", expected: true},
+			{name: "only read@admin left", roles: []admin.DatabaseUserRole{readAdmin}, expected: false},
+			{name: "only read@admin left with delete enabled", roles: []admin.DatabaseUserRole{readAdmin}, deleteWithReadOnly: true, expected: true},
+			{name: "collection role left with delete enabled", roles: []admin.DatabaseUserRole{readOrders}, deleteWithReadOnly: true, expected: false},
+			{name: "several roles left", roles: []admin.DatabaseUserRole{readAdmin, readWriteSales}, deleteWithReadOnly: true, expected: false},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expected, shouldDeleteDatabaseUser(tt.roles, tt.deleteWithReadOnly))
+			})
+		}
+	})
+	t.Run("addAccessLogActivity", func(t *testing.T) {
+		lastLogin := time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+		tests := []struct {
+			name     string
+			entries  []admin.MongoDBAccessLogs
+			expected map[string]databaseUserActivity
+		}{
+			{
+				name:     "no entries",
+				expected: map[string]databaseUserActivity{},
+			},
+			{
+				name: "successful and failed authentications",
+				entries: []admin.MongoDBAccessLogs{
+					{Username: admin.PtrString("app"), AuthResult: admin.PtrBool(true), Timestamp: admin.PtrString("Sat Mar 01 09:00:00 UTC 2025")},
+					{Username: admin.PtrString("app"), AuthResult: admin.PtrBool(true), Timestamp: admin.PtrString("Sat Mar 01 10:00:00 UTC 2025")},
+					{Username: admin.PtrString("app"), AuthResult: admin.PtrBool(false), Timestamp: admin.PtrString("Sat Mar 01 11:00:00 UTC 2025")},
+					{Username: admin.PtrString("reporting"), AuthResult: admin.PtrBool(false)},
+					{Username: admin.PtrString("reporting"), AuthResult: admin.PtrBool(false)},
+				},
+				expected: map[string]databaseUserActivity{
+					"app":       {LastLogin: lastLogin, FailedAuthCount: 1},
+					"reporting": {FailedAuthCount: 2},
+				},
+			},
+			{
+				name: "entries without a username or a valid timestamp",
+				entries: []admin.MongoDBAccessLogs{
+					{AuthResult: admin.PtrBool(true), Timestamp: admin.PtrString("Sat Mar 01 10:00:00 UTC 2025")},
+					{Username: admin.PtrString("app"), AuthResult: admin.PtrBool(true), Timestamp: admin.PtrString("2025-03-01T10:00:00Z")},
+				},
+				expected: map[string]databaseUserActivity{
+					"app": {},
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				activity := make(map[string]databaseUserActivity)
+				addAccessLogActivity(activity, tt.entries)
+
+				assert.Len(t, activity, len(tt.expected))
+				for username, expected := range tt.expected {
+					assert.True(t, expected.LastLogin.Equal(activity[username].LastLogin), username)
+					assert.Equal(t, expected.FailedAuthCount, activity[username].FailedAuthCount, username)
+				}
+			})
+		}
+	})
+
+	t.Run("projectDatabaseUserActivity", func(t *testing.T) {
+		var unavailable projectDatabaseUserActivity
+		assert.Nil(t, unavailable.of("app"))
+
+		activity := projectDatabaseUserActivity{"app": {FailedAuthCount: 2}}
+		assert.Equal(t, &databaseUserActivity{FailedAuthCount: 2}, activity.of("app"))
+		assert.Equal(t, &databaseUserActivity{}, activity.of("reporting"))
+	})
+}
@@ -20,4 +20,8 @@ const (
 	groupDatabaseAccessAdminEntitlement   = "group-database-access-admin"
 
 	partEntitlement = "part"
+
+	assignedEntitlement = "assigned"
+
+	networkAccessEntitlement = "network-access"
 )
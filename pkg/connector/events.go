@@ -0,0 +1,437 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.mongodb.org/atlas-sdk/v20250312006/admin"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	activityEventFeedId = "atlas_activity"
+
+	// activityEventLookback is how far back the feed reads when neither a cursor nor a start time is provided.
+	activityEventLookback = time.Hour
+	// activityEventMaxWindow is the oldest checkpoint the feed replays events from. Past it, every organization and
+	// project is reported as changed instead, which re-syncs them in full.
+	activityEventMaxWindow = 7 * 24 * time.Hour
+	// activityEventMaxPageSize is the largest page size accepted by the Atlas events endpoints.
+	activityEventMaxPageSize = 500
+	// activityEventScopeRefreshInterval is how long the list of organizations and projects carried in the cursor is
+	// reused before it is listed again.
+	activityEventScopeRefreshInterval = time.Hour
+)
+
+var (
+	_ connectorbuilder.EventFeedsLimited = (*MongoDB)(nil)
+	_ connectorbuilder.EventFeed         = (*activityEventFeed)(nil)
+)
+
+// EventFeeds returns the event feeds provided by the connector.
+func (d *MongoDB) EventFeeds(_ context.Context) []connectorbuilder.EventFeed {
+	return []connectorbuilder.EventFeed{
+		newActivityEventFeed(d.client),
+	}
+}
+
+// activityEventFeed translates the Atlas organization and project activity feeds into baton events.
+type activityEventFeed struct {
+	client *admin.APIClient
+}
+
+func newActivityEventFeed(client *admin.APIClient) *activityEventFeed {
+	return &activityEventFeed{
+		client: client,
+	}
+}
+
+// activityEventScope is an organization, or a project when GroupId is set, whose events are read.
+type activityEventScope struct {
+	OrgId   string `json:"org_id"`
+	GroupId string `json:"group_id,omitempty"`
+}
+
+// activityEventCursor is the resumable position of the feed. Each pass reads the events created between Since and
+// Until from every scope in turn; once all scopes are read, the next pass starts at the previous Until. The scopes
+// are carried from pass to pass and only listed again once they are older than activityEventScopeRefreshInterval.
+type activityEventCursor struct {
+	Since          time.Time            `json:"since"`
+	Until          time.Time            `json:"until,omitzero"`
+	Scopes         []activityEventScope `json:"scopes,omitempty"`
+	ScopesListedAt time.Time            `json:"scopes_listed_at,omitzero"`
+	Scope          int                  `json:"scope,omitempty"`
+	Page           int                  `json:"page,omitempty"`
+}
+
+func parseActivityEventCursor(cursor string) (*activityEventCursor, error) {
+	c := &activityEventCursor{}
+	if cursor == "" {
+		return c, nil
+	}
+
+	if err := json.Unmarshal([]byte(cursor), c); err != nil {
+		return nil, fmt.Errorf("baton-mongodb-atlas: invalid event cursor: %w", err)
+	}
+
+	return c, nil
+}
+
+func (c *activityEventCursor) marshal() (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// nextPass returns the cursor of the pass that follows this one, keeping the listed scopes.
+func (c *activityEventCursor) nextPass() *activityEventCursor {
+	return &activityEventCursor{
+		Since:          c.Until,
+		Scopes:         c.Scopes,
+		ScopesListedAt: c.ScopesListedAt,
+	}
+}
+
+func (f *activityEventFeed) EventFeedMetadata(_ context.Context) *v2.EventFeedMetadata {
+	return v2.EventFeedMetadata_builder{
+		Id: activityEventFeedId,
+		SupportedEventTypes: []v2.EventType{
+			v2.EventType_EVENT_TYPE_USAGE,
+			v2.EventType_EVENT_TYPE_RESOURCE_CHANGE,
+		},
+	}.Build()
+}
+
+// ListEvents returns the events of a single page of a single organization or project.
+func (f *activityEventFeed) ListEvents(
+	ctx context.Context,
+	earliestEvent *timestamppb.Timestamp,
+	pToken *pagination.StreamToken,
+) ([]*v2.Event, *pagination.StreamState, annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	cursor, err := parseActivityEventCursor(pToken.Cursor)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Start a new pass over all organizations and projects.
+	if cursor.Until.IsZero() {
+		if cursor.Since.IsZero() {
+			cursor.Since = time.Now().Add(-activityEventLookback)
+			if earliestEvent != nil {
+				cursor.Since = earliestEvent.AsTime()
+			}
+		}
+		cursor.Until = time.Now()
+		cursor.Scope = 0
+		cursor.Page = 1
+
+		if len(cursor.Scopes) == 0 || cursor.Until.Sub(cursor.ScopesListedAt) > activityEventScopeRefreshInterval {
+			cursor.Scopes, err = f.listScopes(ctx)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			cursor.ScopesListedAt = cursor.Until
+		}
+
+		if cursor.Until.Sub(cursor.Since) > activityEventMaxWindow {
+			l.Info(
+				"baton-mongodb-atlas: event checkpoint is too old, reporting all organizations and projects as changed",
+				zap.Time("since", cursor.Since),
+			)
+
+			nextCursor, err := cursor.nextPass().marshal()
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			return resyncEvents(cursor.Scopes, cursor.Until), &pagination.StreamState{Cursor: nextCursor}, nil, nil
+		}
+	}
+
+	pageSize := pToken.Size
+	if pageSize <= 0 {
+		pageSize = resourcePageSize
+	}
+	pageSize = min(pageSize, activityEventMaxPageSize)
+
+	var events []*v2.Event
+	if cursor.Scope < len(cursor.Scopes) {
+		scope := cursor.Scopes[cursor.Scope]
+
+		l.Debug(
+			"fetching a page of activity events",
+			zap.String("orgId", scope.OrgId),
+			zap.String("groupId", scope.GroupId),
+			zap.Int("pageNum", cursor.Page),
+		)
+
+		var count int
+		events, count, err = f.listScopeEvents(ctx, scope, cursor, pageSize)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		if isLastPage(count, pageSize) {
+			cursor.Scope++
+			cursor.Page = 1
+		} else {
+			cursor.Page++
+		}
+	}
+
+	hasMore := cursor.Scope < len(cursor.Scopes)
+	if !hasMore {
+		cursor = cursor.nextPass()
+	}
+
+	nextCursor, err := cursor.marshal()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return events, &pagination.StreamState{Cursor: nextCursor, HasMore: hasMore}, nil, nil
+}
+
+// resyncEvents reports every scope as changed, for when the events since the checkpoint can no longer be replayed.
+func resyncEvents(scopes []activityEventScope, occurredAt time.Time) []*v2.Event {
+	var events []*v2.Event
+	for _, scope := range scopes {
+		resourceId := &v2.ResourceId{ResourceType: organizationResourceType.Id, Resource: scope.OrgId}
+		var parentResourceId *v2.ResourceId
+		if scope.GroupId != "" {
+			parentResourceId = resourceId
+			resourceId = &v2.ResourceId{ResourceType: projectResourceType.Id, Resource: scope.GroupId}
+		}
+
+		events = append(events, v2.Event_builder{
+			Id:         fmt.Sprintf("resync:%s:%s:%d", resourceId.ResourceType, resourceId.Resource, occurredAt.Unix()),
+			OccurredAt: timestamppb.New(occurredAt),
+			ResourceChangeEvent: v2.ResourceChangeEvent_builder{
+				ResourceId:       resourceId,
+				ParentResourceId: parentResourceId,
+			}.Build(),
+		}.Build())
+	}
+
+	return events
+}
+
+// listScopes returns every organization visible to the connector followed by its projects.
+func (f *activityEventFeed) listScopes(ctx context.Context) ([]activityEventScope, error) {
+	var scopes []activityEventScope
+
+	for page := 1; ; page++ {
+		organizations, resp, err := f.client.OrganizationsApi.ListOrganizations(ctx).PageNum(page).ItemsPerPage(resourcePageSize).Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			return nil, fmt.Errorf("failed to list organizations: %w", parseToUHttpError(resp, err))
+		}
+
+		for _, organization := range organizations.GetResults() {
+			scopes = append(scopes, activityEventScope{OrgId: organization.GetId()})
+		}
+
+		if isLastPage(len(organizations.GetResults()), resourcePageSize) {
+			break
+		}
+	}
+
+	for page := 1; ; page++ {
+		projects, resp, err := f.client.ProjectsApi.ListProjects(ctx).PageNum(page).ItemsPerPage(resourcePageSize).Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			return nil, fmt.Errorf("failed to list projects: %w", parseToUHttpError(resp, err))
+		}
+
+		for _, project := range projects.GetResults() {
+			scopes = append(scopes, activityEventScope{OrgId: project.GetOrgId(), GroupId: project.GetId()})
+		}
+
+		if isLastPage(len(projects.GetResults()), resourcePageSize) {
+			break
+		}
+	}
+
+	return scopes, nil
+}
+
+// listScopeEvents returns the translated events of one page of the scope's activity feed, and the number of
+// Atlas events on that page.
+func (f *activityEventFeed) listScopeEvents(
+	ctx context.Context,
+	scope activityEventScope,
+	cursor *activityEventCursor,
+	pageSize int,
+) ([]*v2.Event, int, error) {
+	var atlasEvents []activityEvent
+
+	if scope.GroupId == "" {
+		page, resp, err := f.client.EventsApi.ListOrganizationEvents(ctx, scope.OrgId).
+			MinDate(cursor.Since).
+			MaxDate(cursor.Until).
+			PageNum(cursor.Page).
+			ItemsPerPage(pageSize).
+			Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to list organization events: %w", parseToUHttpError(resp, err))
+		}
+
+		for _, event := range page.GetResults() {
+			atlasEvents = append(atlasEvents, activityEvent{
+				Id:             event.GetId(),
+				Created:        event.GetCreated(),
+				EventTypeName:  event.GetEventTypeName(),
+				OrgId:          scope.OrgId,
+				GroupId:        event.GetGroupId(),
+				TeamId:         event.GetTeamId(),
+				UserId:         event.GetUserId(),
+				Username:       event.GetUsername(),
+				DbUserUsername: event.GetDbUserUsername(),
+			})
+		}
+	} else {
+		page, resp, err := f.client.EventsApi.ListProjectEvents(ctx, scope.GroupId).
+			MinDate(cursor.Since).
+			MaxDate(cursor.Until).
+			PageNum(cursor.Page).
+			ItemsPerPage(pageSize).
+			Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to list project events: %w", parseToUHttpError(resp, err))
+		}
+
+		for _, event := range page.GetResults() {
+			atlasEvents = append(atlasEvents, activityEvent{
+				Id:             event.GetId(),
+				Created:        event.GetCreated(),
+				EventTypeName:  event.GetEventTypeName(),
+				OrgId:          scope.OrgId,
+				GroupId:        scope.GroupId,
+				TeamId:         event.GetTeamId(),
+				UserId:         event.GetUserId(),
+				Username:       event.GetUsername(),
+				DbUserUsername: event.GetDbUserUsername(),
+			})
+		}
+	}
+
+	var events []*v2.Event
+	for _, event := range atlasEvents {
+		events = append(events, event.toBatonEvents()...)
+	}
+
+	return events, len(atlasEvents), nil
+}
+
+// activityEvent holds the fields shared by organization and project events.
+type activityEvent struct {
+	Id             string
+	Created        time.Time
+	EventTypeName  string
+	OrgId          string
+	GroupId        string
+	TeamId         string
+	UserId         string
+	Username       string
+	DbUserUsername string
+}
+
+// changedResource returns the resource whose grants or membership were changed by the event, and its parent.
+// It returns nil for events that do not affect synced access.
+func (e activityEvent) changedResource() (*v2.ResourceId, *v2.ResourceId) {
+	orgId := &v2.ResourceId{ResourceType: organizationResourceType.Id, Resource: e.OrgId}
+
+	switch e.EventTypeName {
+	case "JOINED_ORG", "INVITED_TO_ORG", "REMOVED_FROM_ORG",
+		"TEAM_CREATED", "TEAM_DELETED", "TEAM_NAME_CHANGED":
+		return orgId, nil
+
+	case "USER_ROLES_CHANGED_AUDIT":
+		if e.GroupId == "" {
+			return orgId, nil
+		}
+		return &v2.ResourceId{ResourceType: projectResourceType.Id, Resource: e.GroupId}, orgId
+
+	case "JOINED_GROUP", "INVITED_TO_GROUP", "REMOVED_FROM_GROUP",
+		"TEAM_ADDED_TO_GROUP", "TEAM_REMOVED_FROM_GROUP", "TEAM_ROLES_MODIFIED":
+		if e.GroupId == "" {
+			return nil, nil
+		}
+		return &v2.ResourceId{ResourceType: projectResourceType.Id, Resource: e.GroupId}, orgId
+
+	case "JOINED_TEAM", "REMOVED_FROM_TEAM":
+		if e.TeamId == "" {
+			return nil, nil
+		}
+		return newTeamResourceId(e.OrgId, e.TeamId), orgId
+
+	case "MONGODB_USER_ADDED", "MONGODB_USER_DELETED", "MONGODB_USER_UPDATED":
+		if e.GroupId == "" || e.DbUserUsername == "" {
+			return nil, nil
+		}
+		return &v2.ResourceId{ResourceType: databaseUserResourceType.Id, Resource: e.DbUserUsername},
+			&v2.ResourceId{ResourceType: projectResourceType.Id, Resource: e.GroupId}
+	}
+
+	return nil, nil
+}
+
+// toBatonEvents translates a membership, role, team or database user event into a resource change event, plus a
+// usage event attributing the activity to the console user that performed it.
+func (e activityEvent) toBatonEvents() []*v2.Event {
+	resourceId, parentResourceId := e.changedResource()
+	if resourceId == nil {
+		return nil
+	}
+
+	occurredAt := timestamppb.New(e.Created)
+
+	events := []*v2.Event{
+		v2.Event_builder{
+			Id:         e.Id,
+			OccurredAt: occurredAt,
+			ResourceChangeEvent: v2.ResourceChangeEvent_builder{
+				ResourceId:       resourceId,
+				ParentResourceId: parentResourceId,
+			}.Build(),
+		}.Build(),
+	}
+
+	if e.UserId != "" {
+		actorName := e.Username
+		if actorName == "" {
+			actorName = e.UserId
+		}
+
+		// The activity happened in the project when the event has one, otherwise in the organization.
+		targetId := &v2.ResourceId{ResourceType: organizationResourceType.Id, Resource: e.OrgId}
+		if e.GroupId != "" {
+			targetId = &v2.ResourceId{ResourceType: projectResourceType.Id, Resource: e.GroupId}
+		}
+
+		events = append(events, v2.Event_builder{
+			Id:         e.Id + ":usage",
+			OccurredAt: occurredAt,
+			UsageEvent: v2.UsageEvent_builder{
+				TargetResource: v2.Resource_builder{Id: targetId}.Build(),
+				ActorResource: v2.Resource_builder{
+					Id:          &v2.ResourceId{ResourceType: userResourceType.Id, Resource: e.UserId},
+					DisplayName: actorName,
+				}.Build(),
+			}.Build(),
+		}.Build())
+	}
+
+	return events
+}
@@ -0,0 +1,108 @@
+package connector
+
+import (
+	"testing"
+	"time"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivityEvents(t *testing.T) {
+	since := time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC)
+	until := since.Add(time.Hour)
+	scopes := []activityEventScope{
+		{OrgId: "org"},
+		{OrgId: "org", GroupId: "project"},
+	}
+
+	t.Run("cursor", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			cursor *activityEventCursor
+		}{
+			{
+				name:   "start of the feed",
+				cursor: &activityEventCursor{Since: since},
+			},
+			{
+				name: "pass in progress",
+				cursor: &activityEventCursor{
+					Since:          since,
+					Until:          until,
+					Scopes:         scopes,
+					ScopesListedAt: since,
+					Scope:          1,
+					Page:           3,
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				data, err := tt.cursor.marshal()
+				require.NoError(t, err)
+
+				cursor, err := parseActivityEventCursor(data)
+				require.NoError(t, err)
+				assert.Equal(t, tt.cursor, cursor)
+			})
+		}
+
+		t.Run("empty cursor", func(t *testing.T) {
+			cursor, err := parseActivityEventCursor("")
+			require.NoError(t, err)
+			assert.Equal(t, &activityEventCursor{}, cursor)
+		})
+
+		t.Run("invalid cursor", func(t *testing.T) {
+			_, err := parseActivityEventCursor("not json")
+			assert.Error(t, err)
+		})
+
+		t.Run("next pass keeps the scopes", func(t *testing.T) {
+			cursor := &activityEventCursor{Since: since, Until: until, Scopes: scopes, ScopesListedAt: since, Scope: 2, Page: 1}
+			assert.Equal(t, &activityEventCursor{Since: until, Scopes: scopes, ScopesListedAt: since}, cursor.nextPass())
+		})
+	})
+
+	t.Run("resyncEvents", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			scopes   []activityEventScope
+			expected []*v2.ResourceId
+			parents  []*v2.ResourceId
+		}{
+			{
+				name: "no scopes",
+			},
+			{
+				name:   "organization and project",
+				scopes: scopes,
+				expected: []*v2.ResourceId{
+					{ResourceType: organizationResourceType.Id, Resource: "org"},
+					{ResourceType: projectResourceType.Id, Resource: "project"},
+				},
+				parents: []*v2.ResourceId{
+					nil,
+					{ResourceType: organizationResourceType.Id, Resource: "org"},
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				events := resyncEvents(tt.scopes, until)
+				require.Len(t, events, len(tt.expected))
+
+				for i, event := range events {
+					assert.Equal(t, until, event.GetOccurredAt().AsTime())
+					assert.Equal(t, tt.expected[i].GetResourceType(), event.GetResourceChangeEvent().GetResourceId().GetResourceType())
+					assert.Equal(t, tt.expected[i].GetResource(), event.GetResourceChangeEvent().GetResourceId().GetResource())
+					assert.Equal(t, tt.parents[i].GetResource(), event.GetResourceChangeEvent().GetParentResourceId().GetResource())
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,138 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.mongodb.org/atlas-sdk/v20250312006/admin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const identityProviderStatusActive = "ACTIVE"
+
+// organizationFederation describes how the console users of an organization authenticate. Atlas routes every login
+// from a verified domain associated with an active identity provider through that identity provider.
+type organizationFederation struct {
+	// identityProviders holds the active identity provider for each verified federated domain.
+	identityProviders map[string]admin.FederationIdentityProvider
+	// connectedOrgConfig is the organization's connected configuration, or nil when the organization is not connected
+	// to the federation.
+	connectedOrgConfig *admin.ConnectedOrgConfig
+}
+
+// getOrganizationFederation reads the federation settings, identity providers and connected organization configuration
+// of an organization. It returns an empty federation when the organization does not use federated authentication.
+func getOrganizationFederation(ctx context.Context, client *admin.APIClient, orgId string) (*organizationFederation, error) {
+	federation := &organizationFederation{
+		identityProviders: make(map[string]admin.FederationIdentityProvider),
+	}
+
+	settings, resp, err := client.FederatedAuthenticationApi.GetFederationSettings(ctx, orgId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		err = parseToUHttpError(resp, err)
+		if status.Code(err) == codes.NotFound {
+			return federation, nil
+		}
+		return nil, fmt.Errorf("baton-mongodb-atlas: failed to get federation settings: %w", err)
+	}
+
+	federationSettingsId := settings.GetId()
+	verifiedDomains := settings.GetFederatedDomains()
+
+	for page := 1; ; page++ {
+		identityProviders, resp, err := client.FederatedAuthenticationApi.ListIdentityProviders(ctx, federationSettingsId).
+			PageNum(page).
+			ItemsPerPage(resourcePageSize).
+			Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			return nil, fmt.Errorf("baton-mongodb-atlas: failed to list identity providers: %w", parseToUHttpError(resp, err))
+		}
+
+		federation.addIdentityProviders(identityProviders.GetResults(), verifiedDomains)
+
+		if isLastPage(len(identityProviders.GetResults()), resourcePageSize) {
+			break
+		}
+	}
+
+	for page := 1; federation.connectedOrgConfig == nil; page++ {
+		configs, resp, err := client.FederatedAuthenticationApi.ListConnectedOrgConfigs(ctx, federationSettingsId).
+			PageNum(page).
+			ItemsPerPage(resourcePageSize).
+			Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			return nil, fmt.Errorf("baton-mongodb-atlas: failed to list connected organization configurations: %w", parseToUHttpError(resp, err))
+		}
+
+		for _, config := range configs.GetResults() {
+			if config.OrgId == orgId {
+				federation.connectedOrgConfig = &config
+				break
+			}
+		}
+
+		if isLastPage(len(configs.GetResults()), resourcePageSize) {
+			break
+		}
+	}
+
+	return federation, nil
+}
+
+// addIdentityProviders records the active identity providers for each of their associated domains that is a verified
+// federated domain. Domains are matched case-insensitively.
+func (f *organizationFederation) addIdentityProviders(identityProviders []admin.FederationIdentityProvider, verifiedDomains []string) {
+	for _, identityProvider := range identityProviders {
+		if identityProvider.GetStatus() != identityProviderStatusActive {
+			continue
+		}
+
+		for _, domain := range identityProvider.GetAssociatedDomains() {
+			domain = strings.ToLower(domain)
+			if slices.ContainsFunc(verifiedDomains, func(d string) bool { return strings.EqualFold(d, domain) }) {
+				f.identityProviders[domain] = identityProvider
+			}
+		}
+	}
+}
+
+// identityProviderFor returns the identity provider that authenticates the given username, if any.
+func (f *organizationFederation) identityProviderFor(username string) (string, *admin.FederationIdentityProvider) {
+	_, domain, ok := strings.Cut(username, "@")
+	if !ok {
+		return "", nil
+	}
+
+	domain = strings.ToLower(domain)
+	identityProvider, ok := f.identityProviders[domain]
+	if !ok {
+		return domain, nil
+	}
+
+	return domain, &identityProvider
+}
+
+// profile returns the user profile fields describing how the user authenticates.
+func (f *organizationFederation) profile(username string) map[string]interface{} {
+	domain, identityProvider := f.identityProviderFor(username)
+
+	profile := map[string]interface{}{
+		"sso_enabled": identityProvider != nil,
+	}
+
+	if identityProvider != nil {
+		profile["federated_domain"] = domain
+		profile["identity_provider_id"] = identityProvider.GetId()
+		profile["identity_provider_name"] = identityProvider.GetDisplayName()
+		profile["identity_provider_protocol"] = identityProvider.GetProtocol()
+	}
+
+	if f.connectedOrgConfig != nil {
+		profile["domain_restriction_enabled"] = f.connectedOrgConfig.DomainRestrictionEnabled
+	}
+
+	return profile
+}
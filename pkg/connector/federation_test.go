@@ -0,0 +1,98 @@
+package connector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/atlas-sdk/v20250312006/admin"
+)
+
+func TestOrganizationFederation(t *testing.T) {
+	okta := admin.FederationIdentityProvider{
+		Id:                "okta",
+		DisplayName:       admin.PtrString("Okta"),
+		Protocol:          admin.PtrString("SAML"),
+		Status:            admin.PtrString(identityProviderStatusActive),
+		AssociatedDomains: &[]string{"Example.com", "unverified.com"},
+	}
+	inactive := admin.FederationIdentityProvider{
+		Id:                "inactive",
+		Status:            admin.PtrString("INACTIVE"),
+		AssociatedDomains: &[]string{"example.org"},
+	}
+
+	federation := &organizationFederation{
+		identityProviders: make(map[string]admin.FederationIdentityProvider),
+	}
+	federation.addIdentityProviders([]admin.FederationIdentityProvider{okta, inactive}, []string{"example.com", "example.org"})
+
+	t.Run("addIdentityProviders", func(t *testing.T) {
+		assert.Len(t, federation.identityProviders, 1)
+		assert.Equal(t, "okta", federation.identityProviders["example.com"].Id)
+	})
+
+	t.Run("identityProviderFor", func(t *testing.T) {
+		tests := []struct {
+			name             string
+			username         string
+			domain           string
+			identityProvider string
+		}{
+			{name: "federated domain", username: "jane@example.com", domain: "example.com", identityProvider: "okta"},
+			{name: "domain in another case", username: "jane@EXAMPLE.com", domain: "example.com", identityProvider: "okta"},
+			{name: "domain of an inactive identity provider", username: "jane@example.org", domain: "example.org"},
+			{name: "unverified domain", username: "jane@unverified.com", domain: "unverified.com"},
+			{name: "username without a domain", username: "jane"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				domain, identityProvider := federation.identityProviderFor(tt.username)
+				assert.Equal(t, tt.domain, domain)
+				if tt.identityProvider == "" {
+					assert.Nil(t, identityProvider)
+				} else {
+					assert.Equal(t, tt.identityProvider, identityProvider.Id)
+				}
+			})
+		}
+	})
+
+	t.Run("profile", func(t *testing.T) {
+		tests := []struct {
+			name               string
+			username           string
+			connectedOrgConfig *admin.ConnectedOrgConfig
+			expected           map[string]interface{}
+		}{
+			{
+				name:     "user without SSO",
+				username: "jane@unverified.com",
+				expected: map[string]interface{}{"sso_enabled": false},
+			},
+			{
+				name:               "SSO user in a connected organization",
+				username:           "jane@example.com",
+				connectedOrgConfig: &admin.ConnectedOrgConfig{DomainRestrictionEnabled: true},
+				expected: map[string]interface{}{
+					"sso_enabled":                true,
+					"federated_domain":           "example.com",
+					"identity_provider_id":       "okta",
+					"identity_provider_name":     "Okta",
+					"identity_provider_protocol": "SAML",
+					"domain_restriction_enabled": true,
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				f := &organizationFederation{
+					identityProviders:  federation.identityProviders,
+					connectedOrgConfig: tt.connectedOrgConfig,
+				}
+				assert.Equal(t, tt.expected, f.profile(tt.username))
+			})
+		}
+	})
+}
@@ -0,0 +1,406 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
+	"github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/conductorone/baton-sdk/pkg/uhttp"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.mongodb.org/atlas-sdk/v20250312006/admin"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// networkAccessDuration is how long an access list entry created by a network access grant lives before Atlas
+	// deletes it, in case the grant is never revoked.
+	networkAccessDuration = 24 * time.Hour
+	networkAccessComment  = "Temporary network access granted by baton-mongodb-atlas"
+)
+
+var _ connectorbuilder.ResourceManagerV2 = (*ipAccessEntryBuilder)(nil)
+
+// openIpAccessEntries are the access list entries that allow connections from any address.
+var openIpAccessEntries = map[string]bool{
+	"0.0.0.0/0": true,
+	"::/0":      true,
+}
+
+type ipAccessEntryBuilder struct {
+	resourceType *v2.ResourceType
+	client       *admin.APIClient
+}
+
+func (o *ipAccessEntryBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return ipAccessEntryResourceType
+}
+
+// ipAccessEntryValue returns the value Atlas identifies an access list entry by: its CIDR block, IP address or AWS
+// security group.
+func ipAccessEntryValue(entry admin.NetworkPermissionEntry) string {
+	switch {
+	case entry.GetCidrBlock() != "":
+		return entry.GetCidrBlock()
+	case entry.GetIpAddress() != "":
+		return entry.GetIpAddress()
+	default:
+		return entry.GetAwsSecurityGroup()
+	}
+}
+
+func newIpAccessEntryResourceId(groupId, entryValue string) *v2.ResourceId {
+	return &v2.ResourceId{
+		ResourceType: ipAccessEntryResourceType.Id,
+		Resource:     fmt.Sprintf("%s:%s", groupId, entryValue),
+	}
+}
+
+func parseIpAccessEntryResourceId(resourceId string) (string, string, error) {
+	groupId, entryValue, ok := strings.Cut(resourceId, ":")
+	if !ok || groupId == "" || entryValue == "" {
+		return "", "", fmt.Errorf("baton-mongodb-atlas: invalid IP access entry resource ID: %s", resourceId)
+	}
+
+	return groupId, entryValue, nil
+}
+
+func newIpAccessEntryResource(projectId *v2.ResourceId, entry admin.NetworkPermissionEntry) (*v2.Resource, error) {
+	entryValue := ipAccessEntryValue(entry)
+	openToInternet := openIpAccessEntries[entryValue]
+
+	profile := map[string]interface{}{
+		"entry":              entryValue,
+		"cidr_block":         entry.GetCidrBlock(),
+		"ip_address":         entry.GetIpAddress(),
+		"aws_security_group": entry.GetAwsSecurityGroup(),
+		"comment":            entry.GetComment(),
+		"project_id":         projectId.Resource,
+		"open_to_internet":   openToInternet,
+	}
+
+	if deleteAfterDate, ok := entry.GetDeleteAfterDateOk(); ok {
+		profile["delete_after_date"] = deleteAfterDate.Format(time.RFC3339)
+	}
+
+	description := entry.GetComment()
+	if openToInternet {
+		description = "Allows connections from any address"
+	}
+
+	resource, err := rs.NewAppResource(
+		entryValue,
+		ipAccessEntryResourceType,
+		newIpAccessEntryResourceId(projectId.Resource, entryValue).Resource,
+		[]rs.AppTraitOption{
+			rs.WithAppProfile(profile),
+		},
+		rs.WithParentResourceID(projectId),
+		rs.WithDescription(description),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+func newIpAccessEntryBuilder(client *admin.APIClient) *ipAccessEntryBuilder {
+	return &ipAccessEntryBuilder{
+		resourceType: ipAccessEntryResourceType,
+		client:       client,
+	}
+}
+
+// List returns the IP access list entries of a project.
+func (o *ipAccessEntryBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, opts rs.SyncOpAttrs) ([]*v2.Resource, *rs.SyncOpResults, error) {
+	if parentResourceID == nil || parentResourceID.ResourceType != projectResourceType.Id {
+		return nil, nil, nil
+	}
+
+	bag, page, err := parsePageToken(opts.PageToken.Token, &v2.ResourceId{ResourceType: o.resourceType.Id})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries, resp, err := o.client.ProjectIPAccessListApi.ListProjectIpAccessLists(
+		ctx,
+		parentResourceID.GetResource(),
+	).PageNum(page).ItemsPerPage(resourcePageSize).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, nil, fmt.Errorf("baton-mongodb-atlas: failed to list project IP access list: %w", parseToUHttpError(resp, err))
+	}
+
+	if entries == nil || entries.Results == nil {
+		return nil, nil, nil
+	}
+
+	var resources []*v2.Resource
+	for _, entry := range *entries.Results {
+		resource, err := newIpAccessEntryResource(parentResourceID, entry)
+		if err != nil {
+			return nil, nil, fmt.Errorf("baton-mongodb-atlas: failed to create IP access entry resource: %w", err)
+		}
+
+		resources = append(resources, resource)
+	}
+
+	if isLastPage(len(*entries.Results), resourcePageSize) {
+		return resources, nil, nil
+	}
+
+	nextPage, err := getPageTokenFromPage(bag, page+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resources, &rs.SyncOpResults{NextPageToken: nextPage}, nil
+}
+
+// Entitlements always returns an empty slice; access list entries are not grantable.
+func (o *ipAccessEntryBuilder) Entitlements(_ context.Context, _ *v2.Resource, _ rs.SyncOpAttrs) ([]*v2.Entitlement, *rs.SyncOpResults, error) {
+	return nil, nil, nil
+}
+
+// Grants always returns an empty slice; access list entries are not grantable.
+func (o *ipAccessEntryBuilder) Grants(_ context.Context, _ *v2.Resource, _ rs.SyncOpAttrs) ([]*v2.Grant, *rs.SyncOpResults, error) {
+	return nil, nil, nil
+}
+
+// Create adds a permanent entry to a project's IP access list. The IP address, CIDR block or AWS security group is
+// read from the "entry" profile field, falling back to the display name, and the optional "comment" profile field is
+// stored with it. The entry is not a network access grant; temporary access is only added by grantNetworkAccess.
+func (o *ipAccessEntryBuilder) Create(ctx context.Context, resource *v2.Resource) (*v2.Resource, annotations.Annotations, error) {
+	parentId := resource.GetParentResourceId()
+	if parentId == nil || parentId.ResourceType != projectResourceType.Id {
+		return nil, nil, uhttp.WrapErrors(codes.InvalidArgument, "baton-mongodb-atlas: IP access entry must have a project parent", fmt.Errorf("parent resource is missing or not a project"))
+	}
+
+	entryValue, ok := rs.GetProfileStringValue(rs.GetProfile(resource), "entry")
+	if !ok || entryValue == "" {
+		entryValue = resource.DisplayName
+	}
+	if entryValue == "" {
+		return nil, nil, uhttp.WrapErrors(codes.InvalidArgument, "baton-mongodb-atlas: entry is required", fmt.Errorf("entry and display name are empty"))
+	}
+
+	entry := newIpAccessListEntry(entryValue)
+	if comment, ok := rs.GetProfileStringValue(rs.GetProfile(resource), "comment"); ok && comment != "" {
+		entry.Comment = admin.PtrString(comment)
+	}
+	entries, resp, err := o.client.ProjectIPAccessListApi.CreateProjectIpAccessList(
+		ctx,
+		parentId.Resource,
+		&[]admin.NetworkPermissionEntry{entry},
+	).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, nil, fmt.Errorf("baton-mongodb-atlas: failed to create IP access list entry: %w", parseToUHttpError(resp, err))
+	}
+
+	// Atlas returns the whole access list and stores IP addresses with their CIDR block, so look the new entry up.
+	for _, e := range entries.GetResults() {
+		if e.GetIpAddress() == entryValue || e.GetCidrBlock() == entryValue || e.GetAwsSecurityGroup() == entryValue {
+			entry = e
+			break
+		}
+	}
+
+	created, err := newIpAccessEntryResource(parentId, entry)
+	if err != nil {
+		return nil, nil, fmt.Errorf("baton-mongodb-atlas: failed to create IP access entry resource: %w", err)
+	}
+
+	return created, nil, nil
+}
+
+// Delete removes an entry from a project's IP access list.
+func (o *ipAccessEntryBuilder) Delete(ctx context.Context, resourceId *v2.ResourceId, _ *v2.ResourceId) (annotations.Annotations, error) {
+	groupId, entryValue, err := parseIpAccessEntryResourceId(resourceId.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.client.ProjectIPAccessListApi.DeleteProjectIpAccessList(ctx, groupId, entryValue).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, fmt.Errorf("baton-mongodb-atlas: failed to delete IP access list entry: %w", parseToUHttpError(resp, err))
+	}
+
+	return nil, nil
+}
+
+// networkAccessGrants emits a network access grant on the project for every temporary entry of its IP access list
+// that was added by the connector. Permanent entries, and temporary entries added outside of the connector, are not
+// grants, so that revoking network access can never remove them.
+func networkAccessGrants(ctx context.Context, client *admin.APIClient, resource *v2.Resource, page int) ([]*v2.Grant, int, error) {
+	entries, resp, err := client.ProjectIPAccessListApi.ListProjectIpAccessLists(
+		ctx,
+		resource.Id.Resource,
+	).PageNum(page).ItemsPerPage(resourcePageSize).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, 0, fmt.Errorf("baton-mongodb-atlas: failed to list project IP access list: %w", parseToUHttpError(resp, err))
+	}
+
+	if entries.Results == nil {
+		return nil, 0, nil
+	}
+
+	var rv []*v2.Grant
+	for _, entry := range *entries.Results {
+		if !isNetworkAccessEntry(entry) {
+			continue
+		}
+
+		entryId := newIpAccessEntryResourceId(resource.Id.Resource, ipAccessEntryValue(entry))
+		rv = append(rv, grant.NewGrant(resource, networkAccessEntitlement, entryId))
+	}
+
+	return rv, len(*entries.Results), nil
+}
+
+// isNetworkAccessEntry reports whether an access list entry is a temporary entry added by the connector.
+func isNetworkAccessEntry(entry admin.NetworkPermissionEntry) bool {
+	return entry.GetComment() == networkAccessComment && entry.HasDeleteAfterDate()
+}
+
+// newNetworkPermissionEntry builds the connector's temporary access list entry for an IP address, CIDR block or AWS
+// security group.
+func newNetworkPermissionEntry(entryValue string, deleteAfterDate time.Time) admin.NetworkPermissionEntry {
+	entry := newIpAccessListEntry(entryValue)
+	entry.Comment = admin.PtrString(networkAccessComment)
+	entry.DeleteAfterDate = admin.PtrTime(deleteAfterDate)
+
+	return entry
+}
+
+// newIpAccessListEntry builds a permanent access list entry for an IP address, CIDR block or AWS security group.
+func newIpAccessListEntry(entryValue string) admin.NetworkPermissionEntry {
+	var entry admin.NetworkPermissionEntry
+
+	switch {
+	case strings.HasPrefix(entryValue, "sg-"):
+		entry.AwsSecurityGroup = admin.PtrString(entryValue)
+	case strings.Contains(entryValue, "/"):
+		entry.CidrBlock = admin.PtrString(entryValue)
+	default:
+		entry.IpAddress = admin.PtrString(entryValue)
+	}
+
+	return entry
+}
+
+// grantNetworkAccess adds the address in the grant request to the project's IP access list as a temporary entry.
+// The address is the one the principal's resource ID refers to, whether or not it is still on the access list. When
+// the connector's temporary entry for it already exists, its expiry is pushed back instead. An address that is
+// already on the access list permanently cannot be granted temporary access.
+func grantNetworkAccess(ctx context.Context, client *admin.APIClient, principal *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
+	groupId, entryValue, err := parseIpAccessEntryResourceId(principal.Id.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	if groupId != entitlement.Resource.Id.Resource {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"baton-mongodb-atlas: IP access entry %s belongs to project %s, not %s",
+			entryValue,
+			groupId,
+			entitlement.Resource.Id.Resource,
+		)
+	}
+
+	existing, err := getNetworkPermissionEntry(ctx, client, groupId, entryValue)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && !isNetworkAccessEntry(*existing) {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"baton-mongodb-atlas: %s is already on the IP access list of project %s as an entry not managed by the connector",
+			entryValue,
+			groupId,
+		)
+	}
+
+	// Adding an entry that is already on the access list updates it, which renews the connector's temporary entry.
+	deleteAfterDate := time.Now().Add(networkAccessDuration)
+	_, resp, err := client.ProjectIPAccessListApi.CreateProjectIpAccessList(
+		ctx,
+		groupId,
+		&[]admin.NetworkPermissionEntry{newNetworkPermissionEntry(entryValue, deleteAfterDate)},
+	).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, fmt.Errorf("baton-mongodb-atlas: failed to create IP access list entry: %w", parseToUHttpError(resp, err))
+	}
+
+	ctxzap.Extract(ctx).Info(
+		"baton-mongodb-atlas: created temporary IP access list entry",
+		zap.String("project_id", groupId),
+		zap.String("entry", entryValue),
+		zap.Time("delete_after_date", deleteAfterDate),
+	)
+
+	return nil, nil
+}
+
+// revokeNetworkAccess removes the connector's temporary entry for the principal's address from the project's IP
+// access list. Entries the connector did not add are never removed.
+func revokeNetworkAccess(ctx context.Context, client *admin.APIClient, g *v2.Grant) (annotations.Annotations, error) {
+	groupId, entryValue, err := parseIpAccessEntryResourceId(g.Principal.Id.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	if groupId != g.Entitlement.Resource.Id.Resource {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"baton-mongodb-atlas: IP access entry %s belongs to project %s, not %s",
+			entryValue,
+			groupId,
+			g.Entitlement.Resource.Id.Resource,
+		)
+	}
+
+	existing, err := getNetworkPermissionEntry(ctx, client, groupId, entryValue)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil || !isNetworkAccessEntry(*existing) {
+		// The entry expired, was already removed, or is not one the connector added.
+		return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+	}
+
+	resp, err := client.ProjectIPAccessListApi.DeleteProjectIpAccessList(ctx, groupId, entryValue).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		err = parseToUHttpError(resp, err)
+		if status.Code(err) == codes.NotFound {
+			return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+		}
+
+		return nil, fmt.Errorf("baton-mongodb-atlas: failed to delete IP access list entry: %w", err)
+	}
+
+	return nil, nil
+}
+
+// getNetworkPermissionEntry returns the project's access list entry for an IP address, CIDR block or AWS security
+// group, or nil when it is not on the access list.
+func getNetworkPermissionEntry(ctx context.Context, client *admin.APIClient, groupId, entryValue string) (*admin.NetworkPermissionEntry, error) {
+	entry, resp, err := client.ProjectIPAccessListApi.GetProjectIpList(ctx, groupId, entryValue).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		err = parseToUHttpError(resp, err)
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("baton-mongodb-atlas: failed to get IP access list entry: %w", err)
+	}
+
+	return entry, nil
+}
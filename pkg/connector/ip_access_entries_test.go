@@ -0,0 +1,130 @@
+package connector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/atlas-sdk/v20250312006/admin"
+)
+
+func TestIpAccessEntries(t *testing.T) {
+	deleteAfterDate := time.Date(2025, time.March, 2, 10, 0, 0, 0, time.UTC)
+
+	t.Run("parseIpAccessEntryResourceId", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			resourceId string
+			groupId    string
+			entryValue string
+			wantErr    bool
+		}{
+			{name: "IP address", resourceId: "project:10.0.0.1", groupId: "project", entryValue: "10.0.0.1"},
+			{name: "CIDR block", resourceId: "project:10.0.0.0/16", groupId: "project", entryValue: "10.0.0.0/16"},
+			{name: "IPv6 CIDR block", resourceId: "project:2001:db8::/32", groupId: "project", entryValue: "2001:db8::/32"},
+			{name: "AWS security group", resourceId: "project:sg-0123456789", groupId: "project", entryValue: "sg-0123456789"},
+			{name: "missing entry", resourceId: "project:", wantErr: true},
+			{name: "missing project", resourceId: ":10.0.0.1", wantErr: true},
+			{name: "no separator", resourceId: "project", wantErr: true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				groupId, entryValue, err := parseIpAccessEntryResourceId(tt.resourceId)
+				if tt.wantErr {
+					assert.Error(t, err)
+					return
+				}
+
+				assert.NoError(t, err)
+				assert.Equal(t, tt.groupId, groupId)
+				assert.Equal(t, tt.entryValue, entryValue)
+				assert.Equal(t, tt.resourceId, newIpAccessEntryResourceId(groupId, entryValue).Resource)
+			})
+		}
+	})
+
+	t.Run("newNetworkPermissionEntry", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			entryValue string
+			expected   admin.NetworkPermissionEntry
+		}{
+			{
+				name:       "IP address",
+				entryValue: "10.0.0.1",
+				expected:   admin.NetworkPermissionEntry{IpAddress: admin.PtrString("10.0.0.1")},
+			},
+			{
+				name:       "CIDR block",
+				entryValue: "10.0.0.0/16",
+				expected:   admin.NetworkPermissionEntry{CidrBlock: admin.PtrString("10.0.0.0/16")},
+			},
+			{
+				name:       "AWS security group",
+				entryValue: "sg-0123456789",
+				expected:   admin.NetworkPermissionEntry{AwsSecurityGroup: admin.PtrString("sg-0123456789")},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				entry := newIpAccessListEntry(tt.entryValue)
+				assert.Equal(t, tt.expected, entry)
+				assert.Equal(t, tt.entryValue, ipAccessEntryValue(entry))
+				assert.False(t, isNetworkAccessEntry(entry))
+
+				tt.expected.Comment = admin.PtrString(networkAccessComment)
+				tt.expected.DeleteAfterDate = admin.PtrTime(deleteAfterDate)
+
+				entry = newNetworkPermissionEntry(tt.entryValue, deleteAfterDate)
+				assert.Equal(t, tt.expected, entry)
+				assert.Equal(t, tt.entryValue, ipAccessEntryValue(entry))
+				assert.True(t, isNetworkAccessEntry(entry))
+			})
+		}
+	})
+
+	t.Run("isNetworkAccessEntry", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			entry    admin.NetworkPermissionEntry
+			expected bool
+		}{
+			{
+				name:     "permanent entry",
+				entry:    admin.NetworkPermissionEntry{IpAddress: admin.PtrString("10.0.0.1"), Comment: admin.PtrString("office")},
+				expected: false,
+			},
+			{
+				name:     "permanent entry with the connector's comment",
+				entry:    admin.NetworkPermissionEntry{IpAddress: admin.PtrString("10.0.0.1"), Comment: admin.PtrString(networkAccessComment)},
+				expected: false,
+			},
+			{
+				name: "temporary entry added outside of the connector",
+				entry: admin.NetworkPermissionEntry{
+					IpAddress:       admin.PtrString("10.0.0.1"),
+					Comment:         admin.PtrString("debugging"),
+					DeleteAfterDate: admin.PtrTime(deleteAfterDate),
+				},
+				expected: false,
+			},
+			{
+				name: "temporary entry added by the connector",
+				entry: admin.NetworkPermissionEntry{
+					IpAddress:       admin.PtrString("10.0.0.1"),
+					Comment:         admin.PtrString(networkAccessComment),
+					DeleteAfterDate: admin.PtrTime(deleteAfterDate),
+				},
+				expected: true,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expected, isNetworkAccessEntry(tt.entry))
+			})
+		}
+	})
+}
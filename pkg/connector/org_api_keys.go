@@ -3,16 +3,30 @@ package connector
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"time"
 
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
 	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/conductorone/baton-sdk/pkg/uhttp"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
 	"go.mongodb.org/atlas-sdk/v20250312006/admin"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // orgApiKeyDetail is the axis-2 detail string for organization API keys, per RFC §2.8
 // (<platform>.<object>.<purpose>, lowercase, dot-delimited).
 const orgApiKeyDetail = "mongodb.org_api_key" //nolint:gosec // G101 false positive: axis-2 detail label, not a credential.
 
+var (
+	_ connectorbuilder.ResourceManagerV2        = (*orgApiKeyBuilder)(nil)
+	_ connectorbuilder.CredentialManagerLimited = (*orgApiKeyBuilder)(nil)
+)
+
 type orgApiKeyBuilder struct {
 	resourceType *v2.ResourceType
 	client       *admin.APIClient
@@ -22,7 +36,31 @@ func (o *orgApiKeyBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
 	return orgApiKeyResourceType
 }
 
-func newOrgApiKeyResource(_ context.Context, organizationId *v2.ResourceId, apiKey admin.ApiKeyUserDetails) (*v2.Resource, error) {
+func newOrgApiKeyResourceId(keyId string) *v2.ResourceId {
+	return &v2.ResourceId{
+		ResourceType: orgApiKeyResourceType.Id,
+		Resource:     keyId,
+	}
+}
+
+// objectIdCreatedAt returns the creation time embedded in the leading four bytes of an Atlas ObjectId.
+// Atlas does not return a creation time for API keys, but their IDs are ObjectIds.
+func objectIdCreatedAt(id string) (time.Time, bool) {
+	if len(id) != 24 {
+		return time.Time{}, false
+	}
+
+	seconds, err := strconv.ParseUint(id[:8], 16, 32)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(int64(seconds), 0).UTC(), true
+}
+
+// newOrgApiKeyResource builds an organization API key resource. A nil access list means it could not be read, and
+// leaves the access list fields unset.
+func newOrgApiKeyResource(_ context.Context, organizationId *v2.ResourceId, apiKey admin.ApiKeyUserDetails, accessList []admin.UserAccessListResponse) (*v2.Resource, error) {
 	keyId := apiKey.GetId()
 
 	displayName := apiKey.GetDesc()
@@ -38,8 +76,51 @@ func newOrgApiKeyResource(_ context.Context, organizationId *v2.ResourceId, apiK
 		rs.WithSecretDetail(orgApiKeyDetail),
 	}
 
+	if createdAt, ok := objectIdCreatedAt(keyId); ok {
+		secretTraits = append(secretTraits, rs.WithSecretCreatedAt(createdAt))
+	}
+
+	// Atlas records usage per access list entry, so the key was last used when its most recent entry was.
+	var lastUsedAt time.Time
+	entries := make([]interface{}, 0, len(accessList))
+	for _, accessListEntry := range accessList {
+		entry := map[string]interface{}{
+			"cidr_block":        accessListEntry.GetCidrBlock(),
+			"ip_address":        accessListEntry.GetIpAddress(),
+			"last_used_address": accessListEntry.GetLastUsedAddress(),
+			"request_count":     accessListEntry.GetCount(),
+		}
+		if accessListEntry.HasCreated() {
+			entry["created_at"] = accessListEntry.GetCreated().Format(time.RFC3339)
+		}
+		if accessListEntry.HasLastUsed() {
+			entry["last_used_at"] = accessListEntry.GetLastUsed().Format(time.RFC3339)
+			if accessListEntry.GetLastUsed().After(lastUsedAt) {
+				lastUsedAt = accessListEntry.GetLastUsed()
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if !lastUsedAt.IsZero() {
+		secretTraits = append(secretTraits, rs.WithSecretLastUsedAt(lastUsedAt))
+	}
+
+	profile := map[string]interface{}{
+		"api_key_id":  keyId,
+		"public_key":  apiKey.GetPublicKey(),
+		"description": apiKey.GetDesc(),
+	}
+
+	if accessList != nil {
+		profile["access_list"] = entries
+		profile["access_list_count"] = len(entries)
+	}
+
 	resourceOpts := []rs.ResourceOption{
 		rs.WithParentResourceID(organizationId),
+		rs.WithResourceProfile(profile),
 	}
 	if publicKey := apiKey.GetPublicKey(); publicKey != "" {
 		resourceOpts = append(resourceOpts, rs.WithDescription(fmt.Sprintf("Organization API key (public key %s)", publicKey)))
@@ -48,7 +129,7 @@ func newOrgApiKeyResource(_ context.Context, organizationId *v2.ResourceId, apiK
 	resource, err := rs.NewSecretResource(
 		displayName,
 		orgApiKeyResourceType,
-		keyId,
+		newOrgApiKeyResourceId(keyId).Resource,
 		secretTraits,
 		resourceOpts...,
 	)
@@ -67,7 +148,7 @@ func newOrgApiKeyBuilder(client *admin.APIClient) *orgApiKeyBuilder {
 }
 
 // List returns the organization's programmatic API keys as secret resources.
-// API keys are read-only static-secret credentials, so they carry no entitlements or grants.
+// The roles held by each key are emitted as grants by the organization and project builders.
 func (o *orgApiKeyBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, opts rs.SyncOpAttrs) ([]*v2.Resource, *rs.SyncOpResults, error) {
 	if parentResourceID == nil {
 		return nil, nil, nil
@@ -92,7 +173,17 @@ func (o *orgApiKeyBuilder) List(ctx context.Context, parentResourceID *v2.Resour
 
 	var resources []*v2.Resource
 	for _, apiKey := range *apiKeys.Results {
-		resource, err := newOrgApiKeyResource(ctx, parentResourceID, apiKey)
+		accessList, err := o.listAccessListEntries(ctx, parentResourceID.GetResource(), apiKey.GetId())
+		if err != nil {
+			ctxzap.Extract(ctx).Warn(
+				"baton-mongodb-atlas: failed to list organization API key access list, skipping access list",
+				zap.String("org_id", parentResourceID.GetResource()),
+				zap.String("api_key_id", apiKey.GetId()),
+				zap.Error(err),
+			)
+		}
+
+		resource, err := newOrgApiKeyResource(ctx, parentResourceID, apiKey, accessList)
 		if err != nil {
 			return nil, nil, fmt.Errorf("baton-mongodb-atlas: failed to create organization API key resource: %w", err)
 		}
@@ -112,12 +203,284 @@ func (o *orgApiKeyBuilder) List(ctx context.Context, parentResourceID *v2.Resour
 	return resources, &rs.SyncOpResults{NextPageToken: nextPage}, nil
 }
 
-// Entitlements always returns an empty slice; API keys are credentials, not grantable resources.
+// listAccessListEntries returns every IP access list entry of the API key.
+func (o *orgApiKeyBuilder) listAccessListEntries(ctx context.Context, orgId, apiKeyId string) ([]admin.UserAccessListResponse, error) {
+	entries := make([]admin.UserAccessListResponse, 0)
+	for page := 1; ; page++ {
+		accessList, resp, err := o.client.ProgrammaticAPIKeysApi.ListApiKeyAccessListsEntries(
+			ctx,
+			orgId,
+			apiKeyId,
+		).PageNum(page).ItemsPerPage(resourcePageSize).Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			return nil, fmt.Errorf("baton-mongodb-atlas: failed to list organization API key access list: %w", parseToUHttpError(resp, err))
+		}
+
+		entries = append(entries, accessList.GetResults()...)
+
+		if isLastPage(len(accessList.GetResults()), resourcePageSize) {
+			return entries, nil
+		}
+	}
+}
+
+// Entitlements always returns an empty slice; API keys are principals, not grantable resources.
 func (o *orgApiKeyBuilder) Entitlements(_ context.Context, _ *v2.Resource, _ rs.SyncOpAttrs) ([]*v2.Entitlement, *rs.SyncOpResults, error) {
 	return nil, nil, nil
 }
 
-// Grants always returns an empty slice; API keys are credentials, not grantable resources.
+// Grants always returns an empty slice; API key roles are emitted by the organization and project builders.
 func (o *orgApiKeyBuilder) Grants(_ context.Context, _ *v2.Resource, _ rs.SyncOpAttrs) ([]*v2.Grant, *rs.SyncOpResults, error) {
 	return nil, nil, nil
 }
+
+// Create creates an organization API key with the description and organization roles in the resource profile.
+// Atlas only returns the private key on creation and resource creation cannot return secrets, so the created key
+// has no usable credentials until it is rotated, which issues a replacement key and returns its private key.
+func (o *orgApiKeyBuilder) Create(ctx context.Context, resource *v2.Resource) (*v2.Resource, annotations.Annotations, error) {
+	parentId := resource.GetParentResourceId()
+	if parentId == nil || parentId.ResourceType != organizationResourceType.Id {
+		return nil, nil, uhttp.WrapErrors(codes.InvalidArgument, "mongo-db-connector: organization API key must have an organization parent", fmt.Errorf("parent resource is missing or not an organization"))
+	}
+
+	profile := rs.GetProfile(resource)
+
+	description, ok := rs.GetProfileStringValue(profile, "description")
+	if !ok || description == "" {
+		description = resource.DisplayName
+	}
+	if description == "" {
+		return nil, nil, uhttp.WrapErrors(codes.InvalidArgument, "mongo-db-connector: description is required", fmt.Errorf("description and display name are empty"))
+	}
+
+	rawRoles, _ := rs.GetProfileStringValue(profile, "roles")
+	roles, err := parseOrganizationRoles(rawRoles)
+	if err != nil {
+		return nil, nil, uhttp.WrapErrors(codes.InvalidArgument, "mongo-db-connector: invalid roles", err)
+	}
+
+	apiKey, resp, err := o.client.ProgrammaticAPIKeysApi.CreateApiKey(
+		ctx,
+		parentId.Resource,
+		&admin.CreateAtlasOrganizationApiKey{
+			Desc:  description,
+			Roles: roles,
+		},
+	).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, nil, fmt.Errorf("baton-mongodb-atlas: failed to create organization API key: %w", parseToUHttpError(resp, err))
+	}
+
+	created, err := newOrgApiKeyResource(ctx, parentId, *apiKey, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("baton-mongodb-atlas: failed to create organization API key resource: %w", err)
+	}
+
+	return created, nil, nil
+}
+
+// Delete deletes an organization API key.
+func (o *orgApiKeyBuilder) Delete(ctx context.Context, resourceId *v2.ResourceId, parentResourceID *v2.ResourceId) (annotations.Annotations, error) {
+	orgId := parentResourceID.GetResource()
+	if orgId == "" {
+		var err error
+		orgId, err = o.findApiKeyOrganization(ctx, resourceId.Resource)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := o.client.ProgrammaticAPIKeysApi.DeleteApiKey(ctx, orgId, resourceId.Resource).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, fmt.Errorf("baton-mongodb-atlas: failed to delete organization API key: %w", parseToUHttpError(resp, err))
+	}
+
+	return nil, nil
+}
+
+// RotateCapabilityDetails advertises rotation without a caller-supplied password; Atlas generates the key pair.
+func (o *orgApiKeyBuilder) RotateCapabilityDetails(_ context.Context) (*v2.CredentialDetailsCredentialRotation, annotations.Annotations, error) {
+	return &v2.CredentialDetailsCredentialRotation{
+		SupportedCredentialOptions: []v2.CapabilityDetailCredentialOption{
+			v2.CapabilityDetailCredentialOption_CAPABILITY_DETAIL_CREDENTIAL_OPTION_NO_PASSWORD,
+		},
+		PreferredCredentialOption: v2.CapabilityDetailCredentialOption_CAPABILITY_DETAIL_CREDENTIAL_OPTION_NO_PASSWORD,
+	}, nil, nil
+}
+
+// Rotate replaces an organization API key. Atlas keys cannot be re-keyed in place, so a replacement key is created
+// with the same description, organization roles, project roles and access list, and the old key is deleted afterwards.
+// If any step after creating the replacement fails, the replacement is deleted and the old key is left in place.
+// The replacement has a new resource ID, which is picked up by the next sync.
+func (o *orgApiKeyBuilder) Rotate(
+	ctx context.Context,
+	resourceId *v2.ResourceId,
+	_ *v2.LocalCredentialOptions,
+) ([]*v2.PlaintextData, annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+	oldKeyId := resourceId.Resource
+
+	orgId, err := o.findApiKeyOrganization(ctx, oldKeyId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	oldKey, resp, err := o.client.ProgrammaticAPIKeysApi.GetApiKey(ctx, orgId, oldKeyId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, nil, fmt.Errorf("baton-mongodb-atlas: failed to get organization API key: %w", parseToUHttpError(resp, err))
+	}
+
+	orgRoles, projectRoles := splitApiKeyRoles(oldKey.GetRoles())
+
+	accessList, err := o.listAccessListEntries(ctx, orgId, oldKeyId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	newKey, resp, err := o.client.ProgrammaticAPIKeysApi.CreateApiKey(
+		ctx,
+		orgId,
+		&admin.CreateAtlasOrganizationApiKey{
+			Desc:  oldKey.GetDesc(),
+			Roles: orgRoles,
+		},
+	).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, nil, fmt.Errorf("baton-mongodb-atlas: failed to create replacement organization API key: %w", parseToUHttpError(resp, err))
+	}
+
+	newKeyId := newKey.GetId()
+	for groupId, roles := range projectRoles {
+		resp, err := o.client.ProgrammaticAPIKeysApi.AddProjectApiKey(
+			ctx,
+			groupId,
+			newKeyId,
+			&[]admin.UserAccessRoleAssignment{
+				{
+					Roles: &roles,
+				},
+			},
+		).Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			return nil, nil, o.deleteReplacementApiKey(ctx, orgId, newKeyId, fmt.Errorf(
+				"baton-mongodb-atlas: failed to add replacement organization API key %s to project %s: %w",
+				newKeyId,
+				groupId,
+				parseToUHttpError(resp, err),
+			))
+		}
+	}
+
+	if accessListRequests := newApiKeyAccessListRequests(accessList); len(accessListRequests) > 0 {
+		_, resp, err := o.client.ProgrammaticAPIKeysApi.CreateApiKeyAccessList(
+			ctx,
+			orgId,
+			newKeyId,
+			&accessListRequests,
+		).Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			return nil, nil, o.deleteReplacementApiKey(ctx, orgId, newKeyId, fmt.Errorf(
+				"baton-mongodb-atlas: failed to copy access list to replacement organization API key %s: %w",
+				newKeyId,
+				parseToUHttpError(resp, err),
+			))
+		}
+	}
+
+	resp, err = o.client.ProgrammaticAPIKeysApi.DeleteApiKey(ctx, orgId, oldKeyId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, nil, o.deleteReplacementApiKey(ctx, orgId, newKeyId, fmt.Errorf(
+			"baton-mongodb-atlas: failed to delete organization API key %s after creating replacement %s: %w",
+			oldKeyId,
+			newKeyId,
+			parseToUHttpError(resp, err),
+		))
+	}
+
+	l.Debug(
+		"rotated organization API key",
+		zap.String("org_id", orgId),
+		zap.String("old_api_key_id", oldKeyId),
+		zap.String("new_api_key_id", newKeyId),
+	)
+
+	return []*v2.PlaintextData{
+		{
+			Name:        "public_key",
+			Description: "The public key of the organization API key",
+			Schema:      "text/plain",
+			Bytes:       []byte(newKey.GetPublicKey()),
+		},
+		{
+			Name:        "private_key",
+			Description: "The private key of the organization API key",
+			Schema:      "text/plain",
+			Bytes:       []byte(newKey.GetPrivateKey()),
+		},
+	}, nil, nil
+}
+
+// splitApiKeyRoles separates an API key's organization roles from its project roles, which are keyed by project ID.
+func splitApiKeyRoles(roles []admin.CloudAccessRoleAssignment) ([]string, map[string][]string) {
+	var orgRoles []string
+	projectRoles := make(map[string][]string)
+	for _, role := range roles {
+		if groupId := role.GetGroupId(); groupId != "" {
+			projectRoles[groupId] = append(projectRoles[groupId], role.GetRoleName())
+			continue
+		}
+		orgRoles = append(orgRoles, role.GetRoleName())
+	}
+
+	return orgRoles, projectRoles
+}
+
+// newApiKeyAccessListRequests converts an API key's access list into the entries to add to its replacement.
+// Atlas reports IP address entries with their CIDR block too, so the CIDR block is preferred.
+func newApiKeyAccessListRequests(entries []admin.UserAccessListResponse) []admin.UserAccessListRequest {
+	var requests []admin.UserAccessListRequest
+	for _, entry := range entries {
+		switch {
+		case entry.GetCidrBlock() != "":
+			requests = append(requests, admin.UserAccessListRequest{CidrBlock: admin.PtrString(entry.GetCidrBlock())})
+		case entry.GetIpAddress() != "":
+			requests = append(requests, admin.UserAccessListRequest{IpAddress: admin.PtrString(entry.GetIpAddress())})
+		}
+	}
+
+	return requests
+}
+
+// deleteReplacementApiKey deletes a replacement key after a failed rotation, so that it does not keep the old key's
+// roles without anyone holding its private key. The rotation error is returned.
+func (o *orgApiKeyBuilder) deleteReplacementApiKey(ctx context.Context, orgId, newKeyId string, rotateErr error) error {
+	resp, err := o.client.ProgrammaticAPIKeysApi.DeleteApiKey(ctx, orgId, newKeyId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		ctxzap.Extract(ctx).Error(
+			"baton-mongodb-atlas: failed to delete replacement organization API key after failed rotation",
+			zap.String("org_id", orgId),
+			zap.String("api_key_id", newKeyId),
+			zap.Error(parseToUHttpError(resp, err)),
+		)
+	}
+
+	return rotateErr
+}
+
+// findApiKeyOrganization returns the ID of the organization that owns the API key.
+// API key resource IDs are not scoped by organization, so the organizations visible to the connector are searched.
+func (o *orgApiKeyBuilder) findApiKeyOrganization(ctx context.Context, apiKeyId string) (string, error) {
+	return findOrganizationId(ctx, o.client, func(orgId string) (bool, error) {
+		_, resp, err := o.client.ProgrammaticAPIKeysApi.GetApiKey(ctx, orgId, apiKeyId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err == nil {
+			return true, nil
+		}
+
+		err = parseToUHttpError(resp, err)
+		if status.Code(err) == codes.NotFound {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("baton-mongodb-atlas: failed to get organization API key: %w", err)
+	})
+}
@@ -0,0 +1,85 @@
+package connector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/atlas-sdk/v20250312006/admin"
+)
+
+func TestOrgApiKeys(t *testing.T) {
+	t.Run("splitApiKeyRoles", func(t *testing.T) {
+		tests := []struct {
+			name         string
+			roles        []admin.CloudAccessRoleAssignment
+			orgRoles     []string
+			projectRoles map[string][]string
+		}{
+			{
+				name:         "no roles",
+				projectRoles: map[string][]string{},
+			},
+			{
+				name: "organization and project roles",
+				roles: []admin.CloudAccessRoleAssignment{
+					{RoleName: admin.PtrString("ORG_MEMBER"), OrgId: admin.PtrString("org")},
+					{RoleName: admin.PtrString("GROUP_READ_ONLY"), GroupId: admin.PtrString("project-a")},
+					{RoleName: admin.PtrString("GROUP_OWNER"), GroupId: admin.PtrString("project-a")},
+					{RoleName: admin.PtrString("GROUP_READ_ONLY"), GroupId: admin.PtrString("project-b")},
+				},
+				orgRoles: []string{"ORG_MEMBER"},
+				projectRoles: map[string][]string{
+					"project-a": {"GROUP_READ_ONLY", "GROUP_OWNER"},
+					"project-b": {"GROUP_READ_ONLY"},
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				orgRoles, projectRoles := splitApiKeyRoles(tt.roles)
+				assert.Equal(t, tt.orgRoles, orgRoles)
+				assert.Equal(t, tt.projectRoles, projectRoles)
+			})
+		}
+	})
+
+	t.Run("newApiKeyAccessListRequests", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			entries  []admin.UserAccessListResponse
+			expected []admin.UserAccessListRequest
+		}{
+			{
+				name: "empty access list",
+			},
+			{
+				name: "IP address reported with its CIDR block",
+				entries: []admin.UserAccessListResponse{
+					{IpAddress: admin.PtrString("10.0.0.1"), CidrBlock: admin.PtrString("10.0.0.1/32")},
+				},
+				expected: []admin.UserAccessListRequest{
+					{CidrBlock: admin.PtrString("10.0.0.1/32")},
+				},
+			},
+			{
+				name: "IP address and CIDR block entries",
+				entries: []admin.UserAccessListResponse{
+					{IpAddress: admin.PtrString("10.0.0.1")},
+					{CidrBlock: admin.PtrString("192.168.0.0/16")},
+					{},
+				},
+				expected: []admin.UserAccessListRequest{
+					{IpAddress: admin.PtrString("10.0.0.1")},
+					{CidrBlock: admin.PtrString("192.168.0.0/16")},
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expected, newApiKeyAccessListRequests(tt.entries))
+			})
+		}
+	})
+}
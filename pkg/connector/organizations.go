@@ -3,6 +3,8 @@ package connector
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
 	"go.uber.org/zap"
@@ -40,6 +42,7 @@ var (
 type organizationBuilder struct {
 	resourceType *v2.ResourceType
 	client       *admin.APIClient
+	roleMappings *roleMappingCache
 }
 
 func (o *organizationBuilder) ResourceType(context context.Context) *v2.ResourceType {
@@ -58,6 +61,8 @@ func newOrganizationResource(organization admin.AtlasOrganization) (*v2.Resource
 			&v2.ChildResourceType{ResourceTypeId: teamResourceType.Id},
 			&v2.ChildResourceType{ResourceTypeId: projectResourceType.Id},
 			&v2.ChildResourceType{ResourceTypeId: orgApiKeyResourceType.Id},
+			&v2.ChildResourceType{ResourceTypeId: serviceAccountResourceType.Id},
+			&v2.ChildResourceType{ResourceTypeId: roleMappingResourceType.Id},
 		),
 	)
 	if err != nil {
@@ -105,12 +110,27 @@ func (o *organizationBuilder) List(ctx context.Context, parentResourceID *v2.Res
 	return resources, &rs.SyncOpResults{NextPageToken: nextPage}, nil
 }
 
+// Get returns a single organization, so that it can be re-synced when its activity feed reports a change.
+func (o *organizationBuilder) Get(ctx context.Context, resourceId *v2.ResourceId, _ *v2.ResourceId) (*v2.Resource, annotations.Annotations, error) {
+	organization, resp, err := o.client.OrganizationsApi.GetOrganization(ctx, resourceId.Resource).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get organization: %w", parseToUHttpError(resp, err))
+	}
+
+	resource, err := newOrganizationResource(*organization)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create organization resource: %w", err)
+	}
+
+	return resource, nil, nil
+}
+
 func (o *organizationBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ rs.SyncOpAttrs) ([]*v2.Entitlement, *rs.SyncOpResults, error) {
 	var rv []*v2.Entitlement
 
 	for _, e := range organizationUserEntitlements {
 		assigmentOptions := []entitlement.EntitlementOption{
-			entitlement.WithGrantableTo(userResourceType),
+			entitlement.WithGrantableTo(userResourceType, serviceAccountResourceType, orgApiKeyResourceType, roleMappingResourceType),
 			entitlement.WithDescription(fmt.Sprintf("Member of %s organization", resource.DisplayName)),
 			entitlement.WithDisplayName(fmt.Sprintf("%s organization %s", resource.DisplayName, memberEntitlement)),
 		}
@@ -155,6 +175,9 @@ func (o *organizationBuilder) Grants(ctx context.Context, resource *v2.Resource,
 		&v2.ResourceId{ResourceType: teamResourceType.Id},
 		&v2.ResourceId{ResourceType: projectResourceType.Id},
 		&v2.ResourceId{ResourceType: userResourceType.Id},
+		&v2.ResourceId{ResourceType: serviceAccountResourceType.Id},
+		&v2.ResourceId{ResourceType: orgApiKeyResourceType.Id},
+		&v2.ResourceId{ResourceType: roleMappingResourceType.Id},
 	)
 	if err != nil {
 		return nil, nil, err
@@ -185,6 +208,26 @@ func (o *organizationBuilder) Grants(ctx context.Context, resource *v2.Resource,
 		}
 		count = c
 		rv = append(rv, grants...)
+	case serviceAccountResourceType.Id:
+		grants, c, err := o.GrantServiceAccounts(ctx, resource, page)
+		if err != nil {
+			return nil, nil, err
+		}
+		count = c
+		rv = append(rv, grants...)
+	case orgApiKeyResourceType.Id:
+		grants, c, err := o.GrantApiKeys(ctx, resource, page)
+		if err != nil {
+			return nil, nil, err
+		}
+		count = c
+		rv = append(rv, grants...)
+	case roleMappingResourceType.Id:
+		grants, err := o.GrantRoleMappings(ctx, resource)
+		if err != nil {
+			return nil, nil, err
+		}
+		rv = append(rv, grants...)
 	}
 
 	if isLastPage(count, resourcePageSize) {
@@ -206,6 +249,18 @@ func (o *organizationBuilder) Grants(ctx context.Context, resource *v2.Resource,
 }
 
 func (o *organizationBuilder) Grant(ctx context.Context, resource *v2.Resource, entitlement *v2.Entitlement) ([]*v2.Grant, annotations.Annotations, error) {
+	if resource.Id.ResourceType == orgApiKeyResourceType.Id {
+		return o.grantApiKey(ctx, resource, entitlement)
+	}
+
+	if resource.Id.ResourceType == roleMappingResourceType.Id {
+		return o.grantRoleMapping(ctx, resource, entitlement)
+	}
+
+	if resource.Id.ResourceType == serviceAccountResourceType.Id {
+		return o.grantServiceAccount(ctx, resource, entitlement)
+	}
+
 	if resource.Id.ResourceType != userResourceType.Id {
 		return nil, nil, fmt.Errorf("baton-mongodb-atlas: cannot grant to resource type %s", resource.Id.ResourceType)
 	}
@@ -279,6 +334,18 @@ func (o *organizationBuilder) Grant(ctx context.Context, resource *v2.Resource,
 func (o *organizationBuilder) Revoke(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
 	l := ctxzap.Extract(ctx)
 
+	if grant.Principal.Id.ResourceType == orgApiKeyResourceType.Id {
+		return o.revokeApiKey(ctx, grant)
+	}
+
+	if grant.Principal.Id.ResourceType == roleMappingResourceType.Id {
+		return o.revokeRoleMapping(ctx, grant)
+	}
+
+	if grant.Principal.Id.ResourceType == serviceAccountResourceType.Id {
+		return o.revokeServiceAccount(ctx, grant)
+	}
+
 	if grant.Principal.Id.ResourceType != userResourceType.Id {
 		return nil, fmt.Errorf("baton-mongodb-atlas: cannot revoke to resource type %s", grant.Principal.Id.ResourceType)
 	}
@@ -317,6 +384,14 @@ func (o *organizationBuilder) Revoke(ctx context.Context, grant *v2.Grant) (anno
 			zap.String("userId", userId),
 		)
 
+		if response.GetOrgMembershipStatus() == userStatusPending {
+			if err := deleteOrganizationInvitation(ctx, o.client, orgId, response.GetUsername()); err != nil {
+				return nil, err
+			}
+
+			return nil, nil
+		}
+
 		resp, err = o.client.MongoDBCloudUsersApi.RemoveOrganizationUser(ctx, orgId, userId).Execute() //nolint:bodyclose // The SDK handles closing the response body
 		if err != nil {
 			return nil, fmt.Errorf("failed to remove organization user: %w", parseToUHttpError(resp, err))
@@ -342,6 +417,232 @@ func (o *organizationBuilder) Revoke(ctx context.Context, grant *v2.Grant) (anno
 	return nil, nil
 }
 
+// getApiKeyOrganizationRoles returns the organization-level roles held by an API key.
+func (o *organizationBuilder) getApiKeyOrganizationRoles(ctx context.Context, orgId, apiKeyId string) ([]string, error) {
+	apiKey, resp, err := o.client.ProgrammaticAPIKeysApi.GetApiKey(ctx, orgId, apiKeyId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization API key: %w", parseToUHttpError(resp, err))
+	}
+
+	var roles []string
+	for _, role := range apiKey.GetRoles() {
+		if role.GetGroupId() == "" {
+			roles = append(roles, role.GetRoleName())
+		}
+	}
+
+	return roles, nil
+}
+
+func (o *organizationBuilder) updateApiKeyOrganizationRoles(ctx context.Context, orgId, apiKeyId string, roles []string) error {
+	_, resp, err := o.client.ProgrammaticAPIKeysApi.UpdateApiKey(
+		ctx,
+		orgId,
+		apiKeyId,
+		&admin.UpdateAtlasOrganizationApiKey{
+			Roles: &roles,
+		},
+	).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return fmt.Errorf("failed to update organization API key: %w", parseToUHttpError(resp, err))
+	}
+
+	return nil
+}
+
+func (o *organizationBuilder) grantApiKey(ctx context.Context, resource *v2.Resource, entitlement *v2.Entitlement) ([]*v2.Grant, annotations.Annotations, error) {
+	orgId := entitlement.Resource.Id.Resource
+	apiKeyId := resource.Id.Resource
+
+	role, ok := userRolesOrganizationEntitlementMapReversed[entitlement.Slug]
+	if !ok {
+		return nil, nil, fmt.Errorf("baton-mongodb-atlas: unknown entitlement %s", entitlement.Slug)
+	}
+
+	roles, err := o.getApiKeyOrganizationRoles(ctx, orgId, apiKeyId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if slices.Contains(roles, role) {
+		return nil, annotations.New(&v2.GrantAlreadyExists{}), nil
+	}
+
+	if err := o.updateApiKeyOrganizationRoles(ctx, orgId, apiKeyId, append(roles, role)); err != nil {
+		return nil, nil, err
+	}
+
+	newGrant := grant.NewGrant(entitlement.Resource, entitlement.Slug, newOrgApiKeyResourceId(apiKeyId))
+
+	return []*v2.Grant{newGrant}, nil, nil
+}
+
+func (o *organizationBuilder) revokeApiKey(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
+	orgId := grant.Entitlement.Resource.Id.Resource
+	apiKeyId := grant.Principal.Id.Resource
+
+	role, ok := userRolesOrganizationEntitlementMapReversed[grant.Entitlement.Slug]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "baton-mongodb-atlas: unknown entitlement %s", grant.Entitlement.Slug)
+	}
+
+	roles, err := o.getApiKeyOrganizationRoles(ctx, orgId, apiKeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	newRoles := slices.DeleteFunc(slices.Clone(roles), func(r string) bool {
+		return r == role
+	})
+
+	if len(newRoles) == len(roles) {
+		return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+	}
+
+	if len(newRoles) == 0 {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"baton-mongodb-atlas: cannot revoke the last organization role %s from API key %s; delete the API key instead",
+			role,
+			apiKeyId,
+		)
+	}
+
+	if err := o.updateApiKeyOrganizationRoles(ctx, orgId, apiKeyId, newRoles); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// getServiceAccountOrganizationRoles returns the organization roles held by a service account.
+func (o *organizationBuilder) getServiceAccountOrganizationRoles(ctx context.Context, orgId, clientId string) ([]string, error) {
+	serviceAccount, resp, err := o.client.ServiceAccountsApi.GetServiceAccount(ctx, orgId, clientId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service account: %w", parseToUHttpError(resp, err))
+	}
+
+	return serviceAccount.GetRoles(), nil
+}
+
+func (o *organizationBuilder) updateServiceAccountOrganizationRoles(ctx context.Context, orgId, clientId string, roles []string) error {
+	_, resp, err := o.client.ServiceAccountsApi.UpdateServiceAccount(
+		ctx,
+		clientId,
+		orgId,
+		&admin.OrgServiceAccountUpdateRequest{
+			Roles: &roles,
+		},
+	).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return fmt.Errorf("failed to update service account: %w", parseToUHttpError(resp, err))
+	}
+
+	return nil
+}
+
+func (o *organizationBuilder) grantServiceAccount(ctx context.Context, resource *v2.Resource, entitlement *v2.Entitlement) ([]*v2.Grant, annotations.Annotations, error) {
+	role, ok := userRolesOrganizationEntitlementMapReversed[entitlement.Slug]
+	if !ok {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "baton-mongodb-atlas: unknown entitlement %s", entitlement.Slug)
+	}
+
+	orgId, clientId, err := parseServiceAccountResourceId(resource.Id.Resource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	roles, err := o.getServiceAccountOrganizationRoles(ctx, orgId, clientId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if slices.Contains(roles, role) {
+		return nil, annotations.New(&v2.GrantAlreadyExists{}), nil
+	}
+
+	if err := o.updateServiceAccountOrganizationRoles(ctx, orgId, clientId, append(roles, role)); err != nil {
+		return nil, nil, err
+	}
+
+	newGrant := grant.NewGrant(entitlement.Resource, entitlement.Slug, resource.Id)
+
+	return []*v2.Grant{newGrant}, nil, nil
+}
+
+func (o *organizationBuilder) revokeServiceAccount(ctx context.Context, g *v2.Grant) (annotations.Annotations, error) {
+	role, ok := userRolesOrganizationEntitlementMapReversed[g.Entitlement.Slug]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "baton-mongodb-atlas: unknown entitlement %s", g.Entitlement.Slug)
+	}
+
+	orgId, clientId, err := parseServiceAccountResourceId(g.Principal.Id.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := o.getServiceAccountOrganizationRoles(ctx, orgId, clientId)
+	if err != nil {
+		return nil, err
+	}
+
+	newRoles := slices.DeleteFunc(slices.Clone(roles), func(r string) bool {
+		return r == role
+	})
+
+	if len(newRoles) == len(roles) {
+		return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+	}
+
+	if len(newRoles) == 0 {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"baton-mongodb-atlas: cannot revoke the last organization role %s from service account %s; delete the service account in Atlas instead",
+			role,
+			clientId,
+		)
+	}
+
+	if err := o.updateServiceAccountOrganizationRoles(ctx, orgId, clientId, newRoles); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (o *organizationBuilder) grantRoleMapping(ctx context.Context, resource *v2.Resource, entitlement *v2.Entitlement) ([]*v2.Grant, annotations.Annotations, error) {
+	orgId := entitlement.Resource.Id.Resource
+
+	role, ok := userRolesOrganizationEntitlementMapReversed[entitlement.Slug]
+	if !ok {
+		return nil, nil, fmt.Errorf("baton-mongodb-atlas: unknown entitlement %s", entitlement.Slug)
+	}
+
+	annos, err := grantRoleMappingRole(ctx, o.roleMappings, resource.Id.Resource, admin.ConnectedOrgConfigRoleAssignment{
+		OrgId: &orgId,
+		Role:  &role,
+	})
+	if err != nil || annos != nil {
+		return nil, annos, err
+	}
+
+	return []*v2.Grant{grant.NewGrant(entitlement.Resource, entitlement.Slug, resource.Id)}, nil, nil
+}
+
+func (o *organizationBuilder) revokeRoleMapping(ctx context.Context, g *v2.Grant) (annotations.Annotations, error) {
+	orgId := g.Entitlement.Resource.Id.Resource
+
+	role, ok := userRolesOrganizationEntitlementMapReversed[g.Entitlement.Slug]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "baton-mongodb-atlas: unknown entitlement %s", g.Entitlement.Slug)
+	}
+
+	return revokeRoleMappingRole(ctx, o.roleMappings, g.Principal.Id.Resource, admin.ConnectedOrgConfigRoleAssignment{
+		OrgId: &orgId,
+		Role:  &role,
+	})
+}
+
 func (o *organizationBuilder) GrantTeams(ctx context.Context, orgResource *v2.Resource, page int) ([]*v2.Grant, int, error) {
 	teams, resp, err :=
 		o.client.TeamsApi.ListOrganizationTeams(
@@ -454,9 +755,133 @@ func (o *organizationBuilder) GrantUsers(ctx context.Context, orgResource *v2.Re
 	return rv, len(*users.Results), nil
 }
 
-func newOrganizationBuilder(client *admin.APIClient) *organizationBuilder {
+func (o *organizationBuilder) GrantServiceAccounts(ctx context.Context, orgResource *v2.Resource, page int) ([]*v2.Grant, int, error) {
+	serviceAccounts, resp, err := o.client.ServiceAccountsApi.ListServiceAccounts(
+		ctx,
+		orgResource.Id.Resource,
+	).PageNum(page).ItemsPerPage(resourcePageSize).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list service accounts: %w", parseToUHttpError(resp, err))
+	}
+
+	if serviceAccounts.Results == nil {
+		return nil, 0, err
+	}
+
+	var rv []*v2.Grant
+	for _, serviceAccount := range *serviceAccounts.Results {
+		serviceAccountId := newServiceAccountResourceId(orgResource.Id.Resource, serviceAccount.GetClientId())
+
+		for _, roleName := range serviceAccount.GetRoles() {
+			if entitlementTarget, ok := userRolesOrganizationEntitlementMap[roleName]; ok {
+				rv = append(rv, grant.NewGrant(orgResource, entitlementTarget, serviceAccountId))
+			}
+		}
+	}
+
+	return rv, len(*serviceAccounts.Results), nil
+}
+
+// GrantApiKeys emits a grant for every organization role held by a programmatic API key.
+// Project-scoped role assignments are emitted by the project builder.
+func (o *organizationBuilder) GrantApiKeys(ctx context.Context, orgResource *v2.Resource, page int) ([]*v2.Grant, int, error) {
+	apiKeys, resp, err := o.client.ProgrammaticAPIKeysApi.ListApiKeys(
+		ctx,
+		orgResource.Id.Resource,
+	).PageNum(page).ItemsPerPage(resourcePageSize).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list organization API keys: %w", parseToUHttpError(resp, err))
+	}
+
+	if apiKeys.Results == nil {
+		return nil, 0, err
+	}
+
+	var rv []*v2.Grant
+	for _, apiKey := range *apiKeys.Results {
+		apiKeyId := newOrgApiKeyResourceId(apiKey.GetId())
+
+		for _, role := range apiKey.GetRoles() {
+			if role.GetGroupId() != "" {
+				continue
+			}
+
+			if entitlementTarget, ok := userRolesOrganizationEntitlementMap[role.GetRoleName()]; ok {
+				rv = append(rv, grant.NewGrant(orgResource, entitlementTarget, apiKeyId))
+			}
+		}
+	}
+
+	return rv, len(*apiKeys.Results), nil
+}
+
+// GrantRoleMappings emits a grant for every organization role assigned by a federated authentication role mapping.
+// Role mappings are not paginated, so they are read in a single page.
+func (o *organizationBuilder) GrantRoleMappings(ctx context.Context, orgResource *v2.Resource) ([]*v2.Grant, error) {
+	_, mappings, err := o.roleMappings.list(ctx, orgResource.Id.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	return roleMappingGrants(orgResource, orgResource.Id.Resource, "", mappings, userRolesOrganizationEntitlementMap), nil
+}
+
+// organizationDefaultRole is assigned to new API keys and role mappings when no organization roles are requested.
+const organizationDefaultRole = "ORG_READ_ONLY"
+
+// parseOrganizationRoles parses a comma-separated list of organization roles, such as "ORG_OWNER, ORG_MEMBER",
+// defaulting to organizationDefaultRole when the list is empty.
+func parseOrganizationRoles(raw string) ([]string, error) {
+	var roles []string
+	for _, role := range strings.Split(raw, ",") {
+		role = strings.TrimSpace(role)
+		if role == "" {
+			continue
+		}
+
+		if _, ok := userRolesOrganizationEntitlementMap[role]; !ok {
+			return nil, fmt.Errorf("unknown organization role %q", role)
+		}
+
+		roles = append(roles, role)
+	}
+
+	if len(roles) == 0 {
+		roles = []string{organizationDefaultRole}
+	}
+
+	return roles, nil
+}
+
+// findOrganizationId returns the ID of the first organization visible to the connector for which owns reports true.
+// It is used by operations that only receive a resource ID that is not scoped by organization.
+func findOrganizationId(ctx context.Context, client *admin.APIClient, owns func(orgId string) (bool, error)) (string, error) {
+	for page := 1; ; page++ {
+		organizations, resp, err := client.OrganizationsApi.ListOrganizations(ctx).PageNum(page).ItemsPerPage(resourcePageSize).Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			return "", fmt.Errorf("failed to list organizations: %w", parseToUHttpError(resp, err))
+		}
+
+		for _, organization := range organizations.GetResults() {
+			ok, err := owns(organization.GetId())
+			if err != nil {
+				return "", err
+			}
+			if ok {
+				return organization.GetId(), nil
+			}
+		}
+
+		if isLastPage(len(organizations.GetResults()), resourcePageSize) {
+			return "", status.Error(codes.NotFound, "baton-mongodb-atlas: no organization owns the resource")
+		}
+	}
+}
+
+func newOrganizationBuilder(client *admin.APIClient, roleMappings *roleMappingCache) *organizationBuilder {
 	return &organizationBuilder{
 		resourceType: organizationResourceType,
 		client:       client,
+		roleMappings: roleMappings,
 	}
 }
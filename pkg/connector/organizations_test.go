@@ -0,0 +1,36 @@
+package connector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganizations(t *testing.T) {
+	t.Run("parseOrganizationRoles", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			raw      string
+			expected []string
+			wantErr  bool
+		}{
+			{name: "no roles", raw: "", expected: []string{organizationDefaultRole}},
+			{name: "blank entries", raw: " , ", expected: []string{organizationDefaultRole}},
+			{name: "several roles", raw: "ORG_OWNER, ORG_MEMBER", expected: []string{"ORG_OWNER", "ORG_MEMBER"}},
+			{name: "project role", raw: "GROUP_OWNER", wantErr: true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				roles, err := parseOrganizationRoles(tt.raw)
+				if tt.wantErr {
+					assert.Error(t, err)
+					return
+				}
+
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, roles)
+			})
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"go.mongodb.org/atlas-sdk/v20250312006/admin"
+)
+
+// projectApiKeyDetail labels an organization API key's assignment to a project.
+const projectApiKeyDetail = "mongodb.project_api_key" //nolint:gosec // G101 false positive: axis-2 detail label, not a credential.
+
+type projectApiKeyBuilder struct {
+	resourceType *v2.ResourceType
+	client       *admin.APIClient
+}
+
+func (o *projectApiKeyBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return projectApiKeyResourceType
+}
+
+// newProjectApiKeyResourceId builds the ID of an API key's assignment to a project. Every project API key
+// is an organization API key, so the same key appears once per project it is assigned to.
+func newProjectApiKeyResourceId(groupId, keyId string) *v2.ResourceId {
+	return &v2.ResourceId{
+		ResourceType: projectApiKeyResourceType.Id,
+		Resource:     fmt.Sprintf("%s:%s", groupId, keyId),
+	}
+}
+
+func newProjectApiKeyResource(projectId *v2.ResourceId, apiKey admin.ApiKeyUserDetails) (*v2.Resource, error) {
+	keyId := apiKey.GetId()
+
+	displayName := apiKey.GetDesc()
+	if displayName == "" {
+		displayName = apiKey.GetPublicKey()
+	}
+	if displayName == "" {
+		displayName = keyId
+	}
+
+	var roles []string
+	for _, role := range apiKey.GetRoles() {
+		if role.GetGroupId() == projectId.Resource {
+			roles = append(roles, role.GetRoleName())
+		}
+	}
+
+	profile := map[string]interface{}{
+		"api_key_id":  keyId,
+		"public_key":  apiKey.GetPublicKey(),
+		"description": apiKey.GetDesc(),
+		"project_id":  projectId.Resource,
+		"roles":       strings.Join(roles, ", "),
+	}
+
+	secretTraits := []rs.SecretTraitOption{
+		rs.WithSecretType(v2.SecretTrait_CREDENTIAL_TYPE_STATIC_SECRET),
+		rs.WithSecretDetail(projectApiKeyDetail),
+	}
+
+	if createdAt, ok := objectIdCreatedAt(keyId); ok {
+		secretTraits = append(secretTraits, rs.WithSecretCreatedAt(createdAt))
+	}
+
+	resource, err := rs.NewSecretResource(
+		displayName,
+		projectApiKeyResourceType,
+		newProjectApiKeyResourceId(projectId.Resource, keyId).Resource,
+		secretTraits,
+		rs.WithParentResourceID(projectId),
+		rs.WithResourceProfile(profile),
+		rs.WithDescription(fmt.Sprintf("Organization API key %s assigned to the project", keyId)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+func newProjectApiKeyBuilder(client *admin.APIClient) *projectApiKeyBuilder {
+	return &projectApiKeyBuilder{
+		resourceType: projectApiKeyResourceType,
+		client:       client,
+	}
+}
+
+// List returns the API keys assigned to a project. The roles they hold are emitted as grants by the project builder.
+func (o *projectApiKeyBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, opts rs.SyncOpAttrs) ([]*v2.Resource, *rs.SyncOpResults, error) {
+	if parentResourceID == nil || parentResourceID.ResourceType != projectResourceType.Id {
+		return nil, nil, nil
+	}
+
+	bag, page, err := parsePageToken(opts.PageToken.Token, &v2.ResourceId{ResourceType: o.resourceType.Id})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiKeys, resp, err := o.client.ProgrammaticAPIKeysApi.ListProjectApiKeys(
+		ctx,
+		parentResourceID.GetResource(),
+	).PageNum(page).ItemsPerPage(resourcePageSize).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, nil, fmt.Errorf("baton-mongodb-atlas: failed to list project API keys: %w", parseToUHttpError(resp, err))
+	}
+
+	if apiKeys == nil || apiKeys.Results == nil {
+		return nil, nil, nil
+	}
+
+	var resources []*v2.Resource
+	for _, apiKey := range *apiKeys.Results {
+		resource, err := newProjectApiKeyResource(parentResourceID, apiKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("baton-mongodb-atlas: failed to create project API key resource: %w", err)
+		}
+
+		resources = append(resources, resource)
+	}
+
+	if isLastPage(len(*apiKeys.Results), resourcePageSize) {
+		return resources, nil, nil
+	}
+
+	nextPage, err := getPageTokenFromPage(bag, page+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resources, &rs.SyncOpResults{NextPageToken: nextPage}, nil
+}
+
+// Entitlements always returns an empty slice; API keys are credentials, not grantable resources.
+func (o *projectApiKeyBuilder) Entitlements(_ context.Context, _ *v2.Resource, _ rs.SyncOpAttrs) ([]*v2.Entitlement, *rs.SyncOpResults, error) {
+	return nil, nil, nil
+}
+
+// Grants always returns an empty slice; project roles are granted to the owning organization API key.
+func (o *projectApiKeyBuilder) Grants(_ context.Context, _ *v2.Resource, _ rs.SyncOpAttrs) ([]*v2.Grant, *rs.SyncOpResults, error) {
+	return nil, nil, nil
+}
@@ -3,6 +3,7 @@ package connector
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/annotations"
@@ -12,6 +13,8 @@ import (
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
 	"go.mongodb.org/atlas-sdk/v20250312006/admin"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -33,8 +36,10 @@ var (
 )
 
 type projectBuilder struct {
-	resourceType *v2.ResourceType
-	client       *admin.APIClient
+	resourceType                   *v2.ResourceType
+	client                         *admin.APIClient
+	deleteDatabaseUserWithReadOnly bool
+	roleMappings                   *roleMappingCache
 }
 
 func (o *projectBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
@@ -62,6 +67,9 @@ func newProjectResource(ctx context.Context, organizationId *v2.ResourceId, proj
 		rs.WithAnnotation(
 			&v2.ChildResourceType{ResourceTypeId: databaseUserResourceType.Id},
 			&v2.ChildResourceType{ResourceTypeId: mongoClusterResourceType.Id},
+			&v2.ChildResourceType{ResourceTypeId: customDatabaseRoleResourceType.Id},
+			&v2.ChildResourceType{ResourceTypeId: projectApiKeyResourceType.Id},
+			&v2.ChildResourceType{ResourceTypeId: ipAccessEntryResourceType.Id},
 		),
 	)
 	if err != nil {
@@ -71,10 +79,12 @@ func newProjectResource(ctx context.Context, organizationId *v2.ResourceId, proj
 	return resource, nil
 }
 
-func newProjectBuilder(client *admin.APIClient) *projectBuilder {
+func newProjectBuilder(client *admin.APIClient, deleteDatabaseUserWithReadOnly bool, roleMappings *roleMappingCache) *projectBuilder {
 	return &projectBuilder{
-		resourceType: projectResourceType,
-		client:       client,
+		resourceType:                   projectResourceType,
+		client:                         client,
+		deleteDatabaseUserWithReadOnly: deleteDatabaseUserWithReadOnly,
+		roleMappings:                   roleMappings,
 	}
 }
 
@@ -132,13 +142,29 @@ func (p *projectBuilder) List(ctx context.Context, parentResourceID *v2.Resource
 	return resources, &rs.SyncOpResults{NextPageToken: nextPage}, nil
 }
 
+// Get returns a single project, so that it can be re-synced when its activity feed reports a change.
+func (p *projectBuilder) Get(ctx context.Context, resourceId *v2.ResourceId, _ *v2.ResourceId) (*v2.Resource, annotations.Annotations, error) {
+	project, resp, err := p.client.ProjectsApi.GetProject(ctx, resourceId.Resource).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get project: %w", parseToUHttpError(resp, err))
+	}
+
+	organizationId := &v2.ResourceId{ResourceType: organizationResourceType.Id, Resource: project.OrgId}
+	resource, err := newProjectResource(ctx, organizationId, *project)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create project resource: %w", err)
+	}
+
+	return resource, nil, nil
+}
+
 // Entitlements always returns an empty slice for users.
 func (p *projectBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ rs.SyncOpAttrs) ([]*v2.Entitlement, *rs.SyncOpResults, error) {
 	var rv []*v2.Entitlement
 
 	for _, e := range userRolesProjectEntitlementMap {
 		assigmentOptions := []ent.EntitlementOption{
-			ent.WithGrantableTo(userResourceType),
+			ent.WithGrantableTo(userResourceType, teamResourceType, serviceAccountResourceType, orgApiKeyResourceType, roleMappingResourceType),
 			ent.WithDescription(fmt.Sprintf("Member of %s team", resource.DisplayName)),
 			ent.WithDisplayName(fmt.Sprintf("%s team %s", resource.DisplayName, e)),
 		}
@@ -156,12 +182,39 @@ func (p *projectBuilder) Entitlements(_ context.Context, resource *v2.Resource,
 	entitlement := ent.NewAssignmentEntitlement(resource, memberEntitlement, assigmentOptions...)
 	rv = append(rv, entitlement)
 
+	networkAccessOptions := []ent.EntitlementOption{
+		ent.WithGrantableTo(ipAccessEntryResourceType),
+		ent.WithDescription(fmt.Sprintf("Network access to the clusters of %s", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s network access", resource.DisplayName)),
+	}
+
+	rv = append(rv, ent.NewPermissionEntitlement(resource, networkAccessEntitlement, networkAccessOptions...))
+
+	for _, role := range clusterWideDatabaseRoles {
+		databaseRoleOptions := []ent.EntitlementOption{
+			ent.WithGrantableTo(databaseUserResourceType),
+			ent.WithDescription(fmt.Sprintf("Holds the %s database role on every cluster of %s", role, resource.DisplayName)),
+			ent.WithDisplayName(fmt.Sprintf("%s database role %s", resource.DisplayName, role)),
+		}
+
+		rv = append(rv, ent.NewPermissionEntitlement(resource, role, databaseRoleOptions...))
+	}
+
 	return rv, nil, nil
 }
 
 // Grants always returns an empty slice for users since they don't have any entitlements.
 func (p *projectBuilder) Grants(ctx context.Context, resource *v2.Resource, opts rs.SyncOpAttrs) ([]*v2.Grant, *rs.SyncOpResults, error) {
-	bag, page, err := parsePageToken(opts.PageToken.Token, &v2.ResourceId{ResourceType: databaseUserResourceType.Id}, &v2.ResourceId{ResourceType: userResourceType.Id})
+	bag, page, err := parsePageToken(
+		opts.PageToken.Token,
+		&v2.ResourceId{ResourceType: databaseUserResourceType.Id},
+		&v2.ResourceId{ResourceType: userResourceType.Id},
+		&v2.ResourceId{ResourceType: teamResourceType.Id},
+		&v2.ResourceId{ResourceType: serviceAccountResourceType.Id},
+		&v2.ResourceId{ResourceType: orgApiKeyResourceType.Id},
+		&v2.ResourceId{ResourceType: roleMappingResourceType.Id},
+		&v2.ResourceId{ResourceType: ipAccessEntryResourceType.Id},
+	)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -183,6 +236,40 @@ func (p *projectBuilder) Grants(ctx context.Context, resource *v2.Resource, opts
 		}
 		count = c
 		rv = append(rv, grants...)
+	case teamResourceType.Id:
+		grants, c, err := p.GrantTeams(ctx, resource, page)
+		if err != nil {
+			return nil, nil, err
+		}
+		count = c
+		rv = append(rv, grants...)
+	case serviceAccountResourceType.Id:
+		grants, c, err := p.GrantServiceAccounts(ctx, resource, page)
+		if err != nil {
+			return nil, nil, err
+		}
+		count = c
+		rv = append(rv, grants...)
+	case orgApiKeyResourceType.Id:
+		grants, c, err := p.GrantApiKeys(ctx, resource, page)
+		if err != nil {
+			return nil, nil, err
+		}
+		count = c
+		rv = append(rv, grants...)
+	case roleMappingResourceType.Id:
+		grants, err := p.GrantRoleMappings(ctx, resource)
+		if err != nil {
+			return nil, nil, err
+		}
+		rv = append(rv, grants...)
+	case ipAccessEntryResourceType.Id:
+		grants, c, err := networkAccessGrants(ctx, p.client, resource, page)
+		if err != nil {
+			return nil, nil, err
+		}
+		count = c
+		rv = append(rv, grants...)
 	}
 
 	if isLastPage(count, resourcePageSize) {
@@ -233,6 +320,78 @@ func (p *projectBuilder) GrantUsers(ctx context.Context, resource *v2.Resource,
 	return rv, len(*members.Results), nil
 }
 
+// GrantTeams emits a grant for every project role held by a team. The grants are expandable so that
+// members of the team inherit the project role.
+func (p *projectBuilder) GrantTeams(ctx context.Context, resource *v2.Resource, page int) ([]*v2.Grant, int, error) {
+	teams, resp, err := p.client.TeamsApi.ListProjectTeams(
+		ctx,
+		resource.Id.Resource,
+	).PageNum(page).ItemsPerPage(resourcePageSize).IncludeCount(true).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list project teams: %w", parseToUHttpError(resp, err))
+	}
+
+	if teams.Results == nil {
+		return nil, 0, err
+	}
+
+	orgId := resource.GetParentResourceId().GetResource()
+
+	var rv []*v2.Grant
+	for _, team := range *teams.Results {
+		teamId := newTeamResourceId(orgId, team.GetTeamId())
+
+		for _, roleName := range team.GetRoleNames() {
+			entitlement, ok := userRolesProjectEntitlementMap[roleName]
+			if !ok {
+				continue
+			}
+
+			rv = append(rv, grant.NewGrant(
+				resource,
+				entitlement,
+				teamId,
+				grant.WithAnnotation(&v2.GrantExpandable{
+					EntitlementIds:  []string{fmt.Sprintf("team:%s:%s", teamId.Resource, memberEntitlement)},
+					Shallow:         true,
+					ResourceTypeIds: []string{userResourceType.Id},
+				}),
+			))
+		}
+	}
+
+	return rv, len(*teams.Results), nil
+}
+
+func (p *projectBuilder) GrantServiceAccounts(ctx context.Context, resource *v2.Resource, page int) ([]*v2.Grant, int, error) {
+	serviceAccounts, resp, err := p.client.ServiceAccountsApi.ListProjectServiceAccounts(
+		ctx,
+		resource.Id.Resource,
+	).PageNum(page).ItemsPerPage(resourcePageSize).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list project service accounts: %w", parseToUHttpError(resp, err))
+	}
+
+	if serviceAccounts.Results == nil {
+		return nil, 0, err
+	}
+
+	orgId := resource.GetParentResourceId().GetResource()
+
+	var rv []*v2.Grant
+	for _, serviceAccount := range *serviceAccounts.Results {
+		serviceAccountId := newServiceAccountResourceId(orgId, serviceAccount.GetClientId())
+
+		for _, roleName := range serviceAccount.GetRoles() {
+			if entitlement, ok := userRolesProjectEntitlementMap[roleName]; ok {
+				rv = append(rv, grant.NewGrant(resource, entitlement, serviceAccountId))
+			}
+		}
+	}
+
+	return rv, len(*serviceAccounts.Results), nil
+}
+
 func (p *projectBuilder) GrantDatabaseUsers(ctx context.Context, resource *v2.Resource, page int) ([]*v2.Grant, int, error) {
 	members, resp, err := p.client.DatabaseUsersApi.ListDatabaseUsers(
 		ctx,
@@ -254,6 +413,12 @@ func (p *projectBuilder) GrantDatabaseUsers(ctx context.Context, resource *v2.Re
 		}
 
 		rv = append(rv, grant.NewGrant(resource, memberEntitlement, userResource.Id))
+
+		for _, role := range member.GetRoles() {
+			if isClusterWideDatabaseRole(role) {
+				rv = append(rv, grant.NewGrant(resource, role.RoleName, userResource.Id))
+			}
+		}
 	}
 
 	return rv, len(*members.Results), nil
@@ -263,11 +428,26 @@ func (p *projectBuilder) Grant(ctx context.Context, principal *v2.Resource, enti
 	l := ctxzap.Extract(ctx)
 
 	if principal.Id.ResourceType != userResourceType.Id &&
-		principal.Id.ResourceType != databaseUserResourceType.Id {
-		err := fmt.Errorf("only users can be granted to projects: expected %s or %s, got %s", userResourceType.Id, databaseUserResourceType.Id, principal.Id.ResourceType)
+		principal.Id.ResourceType != databaseUserResourceType.Id &&
+		principal.Id.ResourceType != teamResourceType.Id &&
+		principal.Id.ResourceType != serviceAccountResourceType.Id &&
+		principal.Id.ResourceType != orgApiKeyResourceType.Id &&
+		principal.Id.ResourceType != roleMappingResourceType.Id &&
+		principal.Id.ResourceType != ipAccessEntryResourceType.Id {
+		err := fmt.Errorf(
+			"only users, database users, teams, service accounts, API keys, role mappings and IP access entries can be granted to projects: expected %s, %s, %s, %s, %s, %s or %s, got %s",
+			userResourceType.Id,
+			databaseUserResourceType.Id,
+			teamResourceType.Id,
+			serviceAccountResourceType.Id,
+			orgApiKeyResourceType.Id,
+			roleMappingResourceType.Id,
+			ipAccessEntryResourceType.Id,
+			principal.Id.ResourceType,
+		)
 
 		l.Warn(
-			"mongodb connector: only users can be granted to projects",
+			"mongodb connector: only users, database users, teams, service accounts, API keys, role mappings and IP access entries can be granted to projects",
 			zap.Error(err),
 			zap.String("principal_id", principal.Id.Resource),
 			zap.String("principal_type", principal.Id.ResourceType),
@@ -276,6 +456,30 @@ func (p *projectBuilder) Grant(ctx context.Context, principal *v2.Resource, enti
 		return nil, err
 	}
 
+	if principal.Id.ResourceType == teamResourceType.Id {
+		return p.grantTeam(ctx, principal, entitlement)
+	}
+
+	if principal.Id.ResourceType == serviceAccountResourceType.Id {
+		return p.grantServiceAccount(ctx, principal, entitlement)
+	}
+
+	if principal.Id.ResourceType == orgApiKeyResourceType.Id {
+		return p.grantApiKey(ctx, principal, entitlement)
+	}
+
+	if principal.Id.ResourceType == roleMappingResourceType.Id {
+		return p.grantRoleMapping(ctx, principal, entitlement)
+	}
+
+	if principal.Id.ResourceType == ipAccessEntryResourceType.Id {
+		return grantNetworkAccess(ctx, p.client, principal, entitlement)
+	}
+
+	if principal.Id.ResourceType == databaseUserResourceType.Id {
+		return p.grantDatabaseRole(ctx, principal, entitlement)
+	}
+
 	trait, err := rs.GetUserTrait(principal)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user trait: %w", err)
@@ -324,11 +528,45 @@ func (p *projectBuilder) Grant(ctx context.Context, principal *v2.Resource, enti
 func (p *projectBuilder) Revoke(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
 	l := ctxzap.Extract(ctx)
 
+	if grant.Principal.Id.ResourceType == teamResourceType.Id {
+		return p.revokeTeam(ctx, grant)
+	}
+
+	if grant.Principal.Id.ResourceType == serviceAccountResourceType.Id {
+		return p.revokeServiceAccount(ctx, grant)
+	}
+
+	if grant.Principal.Id.ResourceType == orgApiKeyResourceType.Id {
+		return p.revokeApiKey(ctx, grant)
+	}
+
+	if grant.Principal.Id.ResourceType == roleMappingResourceType.Id {
+		return p.revokeRoleMapping(ctx, grant)
+	}
+
+	if grant.Principal.Id.ResourceType == ipAccessEntryResourceType.Id {
+		return revokeNetworkAccess(ctx, p.client, grant)
+	}
+
+	if grant.Principal.Id.ResourceType == databaseUserResourceType.Id {
+		return p.revokeDatabaseRole(ctx, grant)
+	}
+
 	if grant.Principal.Id.ResourceType != userResourceType.Id {
-		err := fmt.Errorf("only users can be revoked from projects: expected %s, got %s", userResourceType.Id, grant.Principal.Id.ResourceType)
+		err := fmt.Errorf(
+			"only users, database users, teams, service accounts, API keys, role mappings and IP access entries can be revoked from projects: expected %s, %s, %s, %s, %s, %s or %s, got %s",
+			userResourceType.Id,
+			databaseUserResourceType.Id,
+			teamResourceType.Id,
+			serviceAccountResourceType.Id,
+			orgApiKeyResourceType.Id,
+			roleMappingResourceType.Id,
+			ipAccessEntryResourceType.Id,
+			grant.Principal.Id.ResourceType,
+		)
 
 		l.Warn(
-			"mongodb connector: only users can be revoked from projects",
+			"mongodb connector: only users, database users, teams, service accounts, API keys, role mappings and IP access entries can be revoked from projects",
 			zap.Error(err),
 			zap.String("principal_id", grant.Principal.Id.Resource),
 			zap.String("principal_type", grant.Principal.Id.ResourceType),
@@ -357,3 +595,580 @@ func (p *projectBuilder) Revoke(ctx context.Context, grant *v2.Grant) (annotatio
 
 	return nil, nil
 }
+
+// grantTeam assigns a project role to a team, adding the team to the project if it is not already part of it.
+func (p *projectBuilder) grantTeam(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	role, ok := projectEntitlementsUserRolesMap[entitlement.Slug]
+	if !ok {
+		return nil, fmt.Errorf("unknown entitlement: entitlement %s is not recognized", entitlement.Slug)
+	}
+
+	_, teamId, err := parseTeamResourceId(principal.Id.Resource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse team resource ID: %w", err)
+	}
+
+	groupId := entitlement.Resource.Id.Resource
+
+	teamRole, resp, err := p.client.TeamsApi.GetProjectTeam(ctx, groupId, teamId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		err = parseToUHttpError(resp, err)
+		if status.Code(err) != codes.NotFound {
+			return nil, fmt.Errorf("failed to get project team: %w", err)
+		}
+
+		// The team is not part of the project yet.
+		_, resp, err = p.client.TeamsApi.AddAllTeamsToProject(
+			ctx,
+			groupId,
+			&[]admin.TeamRole{
+				{
+					TeamId:    &teamId,
+					RoleNames: &[]string{role},
+				},
+			},
+		).Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			err = fmt.Errorf("failed to add team to project: %w", parseToUHttpError(resp, err))
+
+			l.Error(
+				"failed to add team to project",
+				zap.Error(err),
+				zap.String("team_id", teamId),
+				zap.String("project_id", groupId),
+			)
+
+			return nil, err
+		}
+
+		return nil, nil
+	}
+
+	roles := teamRole.GetRoleNames()
+	if slices.Contains(roles, role) {
+		return annotations.New(&v2.GrantAlreadyExists{}), nil
+	}
+
+	newRoles := append(slices.Clone(roles), role)
+	_, resp, err = p.client.TeamsApi.UpdateTeamRoles(
+		ctx,
+		groupId,
+		teamId,
+		&admin.TeamRole{
+			RoleNames: &newRoles,
+		},
+	).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		err = fmt.Errorf("failed to update team project roles: %w", parseToUHttpError(resp, err))
+
+		l.Error(
+			"failed to update team project roles",
+			zap.Error(err),
+			zap.String("team_id", teamId),
+			zap.String("project_id", groupId),
+		)
+
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// revokeTeam removes a single project role from a team, removing the team from the project when it was its last role.
+func (p *projectBuilder) revokeTeam(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	role, ok := projectEntitlementsUserRolesMap[grant.Entitlement.Slug]
+	if !ok {
+		return nil, fmt.Errorf("unknown entitlement: entitlement %s is not recognized", grant.Entitlement.Slug)
+	}
+
+	_, teamId, err := parseTeamResourceId(grant.Principal.Id.Resource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse team resource ID: %w", err)
+	}
+
+	groupId := grant.Entitlement.Resource.Id.Resource
+
+	teamRole, resp, err := p.client.TeamsApi.GetProjectTeam(ctx, groupId, teamId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		err = parseToUHttpError(resp, err)
+		if status.Code(err) == codes.NotFound {
+			return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+		}
+
+		return nil, fmt.Errorf("failed to get project team: %w", err)
+	}
+
+	var newRoles []string
+	for _, r := range teamRole.GetRoleNames() {
+		if r == role {
+			continue
+		}
+		newRoles = append(newRoles, r)
+	}
+
+	if len(newRoles) == len(teamRole.GetRoleNames()) {
+		return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+	}
+
+	if len(newRoles) == 0 {
+		l.Info(
+			"baton-mongodb-atlas: no roles left for team, removing from project",
+			zap.String("project_id", groupId),
+			zap.String("team_id", teamId),
+		)
+
+		resp, err = p.client.TeamsApi.RemoveProjectTeam(ctx, groupId, teamId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove team from project: %w", parseToUHttpError(resp, err))
+		}
+
+		return nil, nil
+	}
+
+	_, resp, err = p.client.TeamsApi.UpdateTeamRoles(
+		ctx,
+		groupId,
+		teamId,
+		&admin.TeamRole{
+			RoleNames: &newRoles,
+		},
+	).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, fmt.Errorf("failed to update team project roles: %w", parseToUHttpError(resp, err))
+	}
+
+	return nil, nil
+}
+
+// GrantApiKeys emits a grant for every role the organization's programmatic API keys hold in the project.
+func (p *projectBuilder) GrantApiKeys(ctx context.Context, resource *v2.Resource, page int) ([]*v2.Grant, int, error) {
+	apiKeys, resp, err := p.client.ProgrammaticAPIKeysApi.ListProjectApiKeys(
+		ctx,
+		resource.Id.Resource,
+	).PageNum(page).ItemsPerPage(resourcePageSize).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list project API keys: %w", parseToUHttpError(resp, err))
+	}
+
+	if apiKeys.Results == nil {
+		return nil, 0, err
+	}
+
+	var rv []*v2.Grant
+	for _, apiKey := range *apiKeys.Results {
+		apiKeyId := newOrgApiKeyResourceId(apiKey.GetId())
+
+		for _, role := range apiKey.GetRoles() {
+			if role.GetGroupId() != resource.Id.Resource {
+				continue
+			}
+
+			if entitlement, ok := userRolesProjectEntitlementMap[role.GetRoleName()]; ok {
+				rv = append(rv, grant.NewGrant(resource, entitlement, apiKeyId))
+			}
+		}
+	}
+
+	return rv, len(*apiKeys.Results), nil
+}
+
+// GrantRoleMappings emits a grant for every project role assigned by a role mapping of the project's organization.
+// Role mappings are not paginated, so they are read in a single page.
+func (p *projectBuilder) GrantRoleMappings(ctx context.Context, resource *v2.Resource) ([]*v2.Grant, error) {
+	if resource.ParentResourceId == nil {
+		return nil, nil
+	}
+
+	orgId := resource.ParentResourceId.Resource
+	_, mappings, err := p.roleMappings.list(ctx, orgId)
+	if err != nil {
+		return nil, err
+	}
+
+	return roleMappingGrants(resource, orgId, resource.Id.Resource, mappings, userRolesProjectEntitlementMap), nil
+}
+
+func (p *projectBuilder) grantRoleMapping(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
+	role, ok := projectEntitlementsUserRolesMap[entitlement.Slug]
+	if !ok {
+		return nil, fmt.Errorf("unknown entitlement: entitlement %s is not recognized", entitlement.Slug)
+	}
+
+	groupId := entitlement.Resource.Id.Resource
+
+	return grantRoleMappingRole(ctx, p.roleMappings, principal.Id.Resource, admin.ConnectedOrgConfigRoleAssignment{
+		GroupId: &groupId,
+		Role:    &role,
+	})
+}
+
+func (p *projectBuilder) revokeRoleMapping(ctx context.Context, g *v2.Grant) (annotations.Annotations, error) {
+	role, ok := projectEntitlementsUserRolesMap[g.Entitlement.Slug]
+	if !ok {
+		return nil, fmt.Errorf("unknown entitlement: entitlement %s is not recognized", g.Entitlement.Slug)
+	}
+
+	groupId := g.Entitlement.Resource.Id.Resource
+
+	return revokeRoleMappingRole(ctx, p.roleMappings, g.Principal.Id.Resource, admin.ConnectedOrgConfigRoleAssignment{
+		GroupId: &groupId,
+		Role:    &role,
+	})
+}
+
+// getApiKeyProjectRoles returns the roles an organization API key holds in the project.
+func (p *projectBuilder) getApiKeyProjectRoles(ctx context.Context, groupId, apiKeyId string) ([]string, error) {
+	project, resp, err := p.client.ProjectsApi.GetProject(ctx, groupId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", parseToUHttpError(resp, err))
+	}
+
+	apiKey, resp, err := p.client.ProgrammaticAPIKeysApi.GetApiKey(ctx, project.OrgId, apiKeyId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization API key: %w", parseToUHttpError(resp, err))
+	}
+
+	var roles []string
+	for _, role := range apiKey.GetRoles() {
+		if role.GetGroupId() == groupId {
+			roles = append(roles, role.GetRoleName())
+		}
+	}
+
+	return roles, nil
+}
+
+func (p *projectBuilder) grantApiKey(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	role, ok := projectEntitlementsUserRolesMap[entitlement.Slug]
+	if !ok {
+		return nil, fmt.Errorf("unknown entitlement: entitlement %s is not recognized", entitlement.Slug)
+	}
+
+	groupId := entitlement.Resource.Id.Resource
+	apiKeyId := principal.Id.Resource
+
+	roles, err := p.getApiKeyProjectRoles(ctx, groupId, apiKeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	if slices.Contains(roles, role) {
+		return annotations.New(&v2.GrantAlreadyExists{}), nil
+	}
+
+	if len(roles) == 0 {
+		// The API key is not part of the project yet.
+		resp, err := p.client.ProgrammaticAPIKeysApi.AddProjectApiKey(
+			ctx,
+			groupId,
+			apiKeyId,
+			&[]admin.UserAccessRoleAssignment{
+				{
+					Roles: &[]string{role},
+				},
+			},
+		).Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			err = fmt.Errorf("failed to add API key to project: %w", parseToUHttpError(resp, err))
+
+			l.Error(
+				"failed to add API key to project",
+				zap.Error(err),
+				zap.String("api_key_id", apiKeyId),
+				zap.String("project_id", groupId),
+			)
+
+			return nil, err
+		}
+
+		return nil, nil
+	}
+
+	newRoles := append(slices.Clone(roles), role)
+	_, resp, err := p.client.ProgrammaticAPIKeysApi.UpdateApiKeyRoles(
+		ctx,
+		groupId,
+		apiKeyId,
+		&admin.UpdateAtlasProjectApiKey{
+			Roles: &newRoles,
+		},
+	).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		err = fmt.Errorf("failed to update API key project roles: %w", parseToUHttpError(resp, err))
+
+		l.Error(
+			"failed to update API key project roles",
+			zap.Error(err),
+			zap.String("api_key_id", apiKeyId),
+			zap.String("project_id", groupId),
+		)
+
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (p *projectBuilder) revokeApiKey(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	role, ok := projectEntitlementsUserRolesMap[grant.Entitlement.Slug]
+	if !ok {
+		return nil, fmt.Errorf("unknown entitlement: entitlement %s is not recognized", grant.Entitlement.Slug)
+	}
+
+	groupId := grant.Entitlement.Resource.Id.Resource
+	apiKeyId := grant.Principal.Id.Resource
+
+	roles, err := p.getApiKeyProjectRoles(ctx, groupId, apiKeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	var newRoles []string
+	for _, r := range roles {
+		if r == role {
+			continue
+		}
+		newRoles = append(newRoles, r)
+	}
+
+	if len(newRoles) == len(roles) {
+		return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+	}
+
+	if len(newRoles) == 0 {
+		l.Info(
+			"baton-mongodb-atlas: no roles left for API key, removing from project",
+			zap.String("project_id", groupId),
+			zap.String("api_key_id", apiKeyId),
+		)
+
+		resp, err := p.client.ProgrammaticAPIKeysApi.RemoveProjectApiKey(ctx, groupId, apiKeyId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove API key from project: %w", parseToUHttpError(resp, err))
+		}
+
+		return nil, nil
+	}
+
+	_, resp, err := p.client.ProgrammaticAPIKeysApi.UpdateApiKeyRoles(
+		ctx,
+		groupId,
+		apiKeyId,
+		&admin.UpdateAtlasProjectApiKey{
+			Roles: &newRoles,
+		},
+	).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, fmt.Errorf("failed to update API key project roles: %w", parseToUHttpError(resp, err))
+	}
+
+	return nil, nil
+}
+
+// getServiceAccountProjectRoles returns the roles a service account holds in the project, or none when it is not part
+// of the project.
+func (p *projectBuilder) getServiceAccountProjectRoles(ctx context.Context, groupId, clientId string) ([]string, error) {
+	serviceAccount, resp, err := p.client.ServiceAccountsApi.GetProjectServiceAccount(ctx, groupId, clientId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		err = parseToUHttpError(resp, err)
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get project service account: %w", err)
+	}
+
+	return serviceAccount.GetRoles(), nil
+}
+
+func (p *projectBuilder) updateServiceAccountProjectRoles(ctx context.Context, groupId, clientId string, roles []string) error {
+	_, resp, err := p.client.ServiceAccountsApi.UpdateProjectServiceAccount(
+		ctx,
+		clientId,
+		groupId,
+		&admin.GroupServiceAccountUpdateRequest{
+			Roles: &roles,
+		},
+	).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return fmt.Errorf("failed to update service account project roles: %w", parseToUHttpError(resp, err))
+	}
+
+	return nil
+}
+
+func (p *projectBuilder) grantServiceAccount(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
+	role, ok := projectEntitlementsUserRolesMap[entitlement.Slug]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "baton-mongodb-atlas: unknown entitlement %s", entitlement.Slug)
+	}
+
+	_, clientId, err := parseServiceAccountResourceId(principal.Id.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	groupId := entitlement.Resource.Id.Resource
+
+	roles, err := p.getServiceAccountProjectRoles(ctx, groupId, clientId)
+	if err != nil {
+		return nil, err
+	}
+
+	if slices.Contains(roles, role) {
+		return annotations.New(&v2.GrantAlreadyExists{}), nil
+	}
+
+	if len(roles) == 0 {
+		// The service account is not part of the project yet.
+		_, resp, err := p.client.ServiceAccountsApi.AddProjectServiceAccount(
+			ctx,
+			clientId,
+			groupId,
+			&admin.GroupServiceAccountRoleAssignment{
+				Roles: []string{role},
+			},
+		).Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			return nil, fmt.Errorf("failed to add service account to project: %w", parseToUHttpError(resp, err))
+		}
+
+		return nil, nil
+	}
+
+	if err := p.updateServiceAccountProjectRoles(ctx, groupId, clientId, append(slices.Clone(roles), role)); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (p *projectBuilder) revokeServiceAccount(ctx context.Context, g *v2.Grant) (annotations.Annotations, error) {
+	role, ok := projectEntitlementsUserRolesMap[g.Entitlement.Slug]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "baton-mongodb-atlas: unknown entitlement %s", g.Entitlement.Slug)
+	}
+
+	_, clientId, err := parseServiceAccountResourceId(g.Principal.Id.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	groupId := g.Entitlement.Resource.Id.Resource
+
+	roles, err := p.getServiceAccountProjectRoles(ctx, groupId, clientId)
+	if err != nil {
+		return nil, err
+	}
+
+	newRoles := slices.DeleteFunc(slices.Clone(roles), func(r string) bool {
+		return r == role
+	})
+
+	if len(newRoles) == len(roles) {
+		return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+	}
+
+	if len(newRoles) == 0 {
+		ctxzap.Extract(ctx).Info(
+			"baton-mongodb-atlas: no roles left for service account, removing from project",
+			zap.String("project_id", groupId),
+			zap.String("client_id", clientId),
+		)
+
+		resp, err := p.client.ServiceAccountsApi.DeleteProjectServiceAccount(ctx, clientId, groupId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove service account from project: %w", parseToUHttpError(resp, err))
+		}
+
+		return nil, nil
+	}
+
+	if err := p.updateServiceAccountProjectRoles(ctx, groupId, clientId, newRoles); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// isClusterWideDatabaseRole reports whether a database user role is a built-in role that applies to every database.
+func isClusterWideDatabaseRole(role admin.DatabaseUserRole) bool {
+	return role.DatabaseName == databaseNameAdmin && !role.HasCollectionName() && slices.Contains(clusterWideDatabaseRoles, role.RoleName)
+}
+
+// grantDatabaseRole adds a cluster-wide built-in role, assigned against the admin database, to a database user.
+func (p *projectBuilder) grantDatabaseRole(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
+	role := admin.DatabaseUserRole{
+		DatabaseName: databaseNameAdmin,
+		RoleName:     entitlement.Slug,
+	}
+	if !isClusterWideDatabaseRole(role) {
+		return nil, fmt.Errorf("unknown entitlement: entitlement %s is not recognized", entitlement.Slug)
+	}
+
+	groupId := entitlement.Resource.Id.Resource
+
+	dbUser, err := getDatabaseUser(ctx, p.client, groupId, principal.Id.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range dbUser.GetRoles() {
+		if isClusterWideDatabaseRole(r) && r.RoleName == role.RoleName {
+			return annotations.New(&v2.GrantAlreadyExists{}), nil
+		}
+	}
+
+	newRoles := append(dbUser.GetRoles(), role)
+	dbUser.Roles = &newRoles
+
+	_, resp, err := p.client.DatabaseUsersApi.UpdateDatabaseUser(ctx, groupId, dbUser.DatabaseName, dbUser.Username, dbUser).
+		Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, fmt.Errorf("failed to update database user: %w", parseToUHttpError(resp, err))
+	}
+
+	return nil, nil
+}
+
+// revokeDatabaseRole removes a cluster-wide built-in role from a database user, deleting the user when no meaningful
+// roles remain.
+func (p *projectBuilder) revokeDatabaseRole(ctx context.Context, g *v2.Grant) (annotations.Annotations, error) {
+	groupId := g.Entitlement.Resource.Id.Resource
+	roleName := g.Entitlement.Slug
+	if !slices.Contains(clusterWideDatabaseRoles, roleName) {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"baton-mongodb-atlas: entitlement %s is not a cluster-wide database role and cannot be revoked from a database user",
+			roleName,
+		)
+	}
+
+	dbUser, err := getDatabaseUser(ctx, p.client, groupId, g.Principal.Id.Resource)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+		}
+		return nil, err
+	}
+
+	newRoles, found := removeDatabaseUserRoles(dbUser.GetRoles(), func(r admin.DatabaseUserRole) bool {
+		return isClusterWideDatabaseRole(r) && r.RoleName == roleName
+	})
+	if !found {
+		return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+	}
+
+	if err := saveDatabaseUserRoles(ctx, p.client, groupId, dbUser, newRoles, p.deleteDatabaseUserWithReadOnly); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
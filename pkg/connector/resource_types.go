@@ -59,7 +59,6 @@ var (
 		DisplayName: "Collection",
 		Description: "MongoDB Collection",
 		Traits:      []v2.ResourceType_Trait{v2.ResourceType_TRAIT_APP},
-		Annotations: getSkipEntitlementsAndGrantsAnnotations(),
 	}
 
 	orgApiKeyResourceType = &v2.ResourceType{
@@ -69,4 +68,51 @@ var (
 		Traits:      []v2.ResourceType_Trait{v2.ResourceType_TRAIT_SECRET},
 		Annotations: getSkipEntitlementsAndGrantsAnnotations(),
 	}
+
+	projectApiKeyResourceType = &v2.ResourceType{
+		Id:          "project_api_key",
+		DisplayName: "Project API Key",
+		Description: "A MongoDB Atlas programmatic API key assigned to a project",
+		Traits:      []v2.ResourceType_Trait{v2.ResourceType_TRAIT_SECRET},
+		Annotations: getSkipEntitlementsAndGrantsAnnotations(),
+	}
+
+	customDatabaseRoleResourceType = &v2.ResourceType{
+		Id:          "custom_database_role",
+		DisplayName: "Custom Database Role",
+		Description: "A MongoDB Atlas project custom database role",
+		Traits:      []v2.ResourceType_Trait{v2.ResourceType_TRAIT_ROLE},
+	}
+
+	serviceAccountResourceType = &v2.ResourceType{
+		Id:          "service_account",
+		DisplayName: "Service Account",
+		Description: "A MongoDB Atlas organization OAuth service account",
+		Traits:      []v2.ResourceType_Trait{v2.ResourceType_TRAIT_USER},
+		Annotations: getSkipEntitlementsAndGrantsAnnotations(),
+	}
+
+	serviceAccountSecretResourceType = &v2.ResourceType{
+		Id:          "service_account_secret",
+		DisplayName: "Service Account Secret",
+		Description: "A MongoDB Atlas service account client secret",
+		Traits:      []v2.ResourceType_Trait{v2.ResourceType_TRAIT_SECRET},
+		Annotations: getSkipEntitlementsAndGrantsAnnotations(),
+	}
+
+	roleMappingResourceType = &v2.ResourceType{
+		Id:          "role_mapping",
+		DisplayName: "Role Mapping",
+		Description: "A MongoDB Atlas federated authentication role mapping for an identity provider group",
+		Traits:      []v2.ResourceType_Trait{v2.ResourceType_TRAIT_GROUP},
+		Annotations: getSkipEntitlementsAndGrantsAnnotations(),
+	}
+
+	ipAccessEntryResourceType = &v2.ResourceType{
+		Id:          "ip_access_entry",
+		DisplayName: "IP Access Entry",
+		Description: "A MongoDB Atlas project IP access list entry",
+		Traits:      []v2.ResourceType_Trait{v2.ResourceType_TRAIT_APP},
+		Annotations: getSkipEntitlementsAndGrantsAnnotations(),
+	}
 )
@@ -0,0 +1,477 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
+	"github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/conductorone/baton-sdk/pkg/uhttp"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.mongodb.org/atlas-sdk/v20250312006/admin"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ connectorbuilder.ResourceManagerV2 = (*roleMappingBuilder)(nil)
+
+type roleMappingBuilder struct {
+	resourceType *v2.ResourceType
+	client       *admin.APIClient
+	roleMappings *roleMappingCache
+}
+
+func (o *roleMappingBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return roleMappingResourceType
+}
+
+// newRoleMappingResourceId builds the role mapping resource ID, which is scoped by organization.
+func newRoleMappingResourceId(orgId, mappingId string) *v2.ResourceId {
+	return &v2.ResourceId{
+		ResourceType: roleMappingResourceType.Id,
+		Resource:     fmt.Sprintf("%s:%s", orgId, mappingId),
+	}
+}
+
+func parseRoleMappingResourceId(resourceId string) (string, string, error) {
+	parts := strings.Split(resourceId, ":")
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid role mapping resource id: %s", resourceId)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+func newRoleMappingResource(organizationId *v2.ResourceId, federationSettingsId string, mapping admin.AuthFederationRoleMapping) (*v2.Resource, error) {
+	var roleAssignments []interface{}
+	for _, assignment := range mapping.GetRoleAssignments() {
+		roleAssignments = append(roleAssignments, map[string]interface{}{
+			"role":       assignment.GetRole(),
+			"org_id":     assignment.GetOrgId(),
+			"project_id": assignment.GetGroupId(),
+		})
+	}
+
+	profile := map[string]interface{}{
+		"role_mapping_id":        mapping.GetId(),
+		"external_group_name":    mapping.GetExternalGroupName(),
+		"federation_settings_id": federationSettingsId,
+		"organization_id":        organizationId.Resource,
+		"role_assignments":       roleAssignments,
+	}
+
+	groupTraits := []rs.GroupTraitOption{
+		rs.WithGroupProfile(profile),
+	}
+
+	resource, err := rs.NewGroupResource(
+		mapping.GetExternalGroupName(),
+		roleMappingResourceType,
+		newRoleMappingResourceId(organizationId.Resource, mapping.GetId()).Resource,
+		groupTraits,
+		rs.WithParentResourceID(organizationId),
+		rs.WithDescription(fmt.Sprintf("Identity provider group %s", mapping.GetExternalGroupName())),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+func newRoleMappingBuilder(client *admin.APIClient, roleMappings *roleMappingCache) *roleMappingBuilder {
+	return &roleMappingBuilder{
+		resourceType: roleMappingResourceType,
+		client:       client,
+		roleMappings: roleMappings,
+	}
+}
+
+// List returns the role mappings of the organization's connected identity provider configuration.
+// The roles they assign are emitted as grants by the organization and project builders.
+func (o *roleMappingBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, _ rs.SyncOpAttrs) ([]*v2.Resource, *rs.SyncOpResults, error) {
+	if parentResourceID == nil || parentResourceID.ResourceType != organizationResourceType.Id {
+		return nil, nil, nil
+	}
+
+	federationSettingsId, mappings, err := o.roleMappings.list(ctx, parentResourceID.Resource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var resources []*v2.Resource
+	for _, mapping := range mappings {
+		resource, err := newRoleMappingResource(parentResourceID, federationSettingsId, mapping)
+		if err != nil {
+			return nil, nil, fmt.Errorf("baton-mongodb-atlas: failed to create role mapping resource: %w", err)
+		}
+
+		resources = append(resources, resource)
+	}
+
+	return resources, nil, nil
+}
+
+// Entitlements always returns an empty slice; role mappings are principals of organization and project roles.
+func (o *roleMappingBuilder) Entitlements(_ context.Context, _ *v2.Resource, _ rs.SyncOpAttrs) ([]*v2.Entitlement, *rs.SyncOpResults, error) {
+	return nil, nil, nil
+}
+
+// Grants always returns an empty slice; the roles a mapping assigns are emitted by the organization and project builders.
+func (o *roleMappingBuilder) Grants(_ context.Context, _ *v2.Resource, _ rs.SyncOpAttrs) ([]*v2.Grant, *rs.SyncOpResults, error) {
+	return nil, nil, nil
+}
+
+// Create creates a role mapping for an identity provider group. Atlas requires every role mapping to assign at least
+// one organization role; project roles are added afterwards by granting project role entitlements to the mapping.
+func (o *roleMappingBuilder) Create(ctx context.Context, resource *v2.Resource) (*v2.Resource, annotations.Annotations, error) {
+	parentId := resource.GetParentResourceId()
+	if parentId == nil || parentId.ResourceType != organizationResourceType.Id {
+		return nil, nil, uhttp.WrapErrors(codes.InvalidArgument, "mongo-db-connector: role mapping must have an organization parent", fmt.Errorf("parent resource is missing or not an organization"))
+	}
+	orgId := parentId.Resource
+
+	profile := rs.GetProfile(resource)
+
+	externalGroupName, ok := rs.GetProfileStringValue(profile, "external_group_name")
+	if !ok || externalGroupName == "" {
+		externalGroupName = resource.DisplayName
+	}
+	if externalGroupName == "" {
+		return nil, nil, uhttp.WrapErrors(codes.InvalidArgument, "mongo-db-connector: external_group_name is required", fmt.Errorf("external group name and display name are empty"))
+	}
+
+	rawRoles, _ := rs.GetProfileStringValue(profile, "roles")
+	roles, err := parseOrganizationRoles(rawRoles)
+	if err != nil {
+		return nil, nil, uhttp.WrapErrors(codes.InvalidArgument, "mongo-db-connector: invalid roles", err)
+	}
+
+	federationSettingsId, err := requireFederationSettingsId(ctx, o.client, orgId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var roleAssignments []admin.ConnectedOrgConfigRoleAssignment
+	for _, role := range roles {
+		roleAssignments = append(roleAssignments, admin.ConnectedOrgConfigRoleAssignment{
+			OrgId: &orgId,
+			Role:  &role,
+		})
+	}
+
+	mapping, resp, err := o.client.FederatedAuthenticationApi.CreateRoleMapping(
+		ctx,
+		federationSettingsId,
+		orgId,
+		&admin.AuthFederationRoleMapping{
+			ExternalGroupName: externalGroupName,
+			RoleAssignments:   &roleAssignments,
+		},
+	).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, nil, fmt.Errorf("baton-mongodb-atlas: failed to create role mapping: %w", parseToUHttpError(resp, err))
+	}
+	o.roleMappings.invalidate(orgId)
+
+	created, err := newRoleMappingResource(parentId, federationSettingsId, *mapping)
+	if err != nil {
+		return nil, nil, fmt.Errorf("baton-mongodb-atlas: failed to create role mapping resource: %w", err)
+	}
+
+	return created, nil, nil
+}
+
+// Delete removes a role mapping.
+func (o *roleMappingBuilder) Delete(ctx context.Context, resourceId *v2.ResourceId, _ *v2.ResourceId) (annotations.Annotations, error) {
+	orgId, mappingId, err := parseRoleMappingResourceId(resourceId.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	federationSettingsId, err := requireFederationSettingsId(ctx, o.client, orgId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.client.FederatedAuthenticationApi.DeleteRoleMapping(ctx, federationSettingsId, mappingId, orgId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, fmt.Errorf("baton-mongodb-atlas: failed to delete role mapping: %w", parseToUHttpError(resp, err))
+	}
+	o.roleMappings.invalidate(orgId)
+
+	return nil, nil
+}
+
+// getFederationSettingsId returns the ID of the federation the organization is connected to, or an empty string
+// when the organization does not use federated authentication.
+func getFederationSettingsId(ctx context.Context, client *admin.APIClient, orgId string) (string, error) {
+	settings, resp, err := client.FederatedAuthenticationApi.GetFederationSettings(ctx, orgId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		err = parseToUHttpError(resp, err)
+		if status.Code(err) == codes.NotFound {
+			return "", nil
+		}
+		return "", fmt.Errorf("baton-mongodb-atlas: failed to get federation settings: %w", err)
+	}
+
+	return settings.GetId(), nil
+}
+
+// requireFederationSettingsId returns the ID of the federation the organization is connected to, failing when the
+// organization does not use federated authentication.
+func requireFederationSettingsId(ctx context.Context, client *admin.APIClient, orgId string) (string, error) {
+	federationSettingsId, err := getFederationSettingsId(ctx, client, orgId)
+	if err != nil {
+		return "", err
+	}
+
+	if federationSettingsId == "" {
+		return "", status.Errorf(codes.FailedPrecondition, "baton-mongodb-atlas: organization %s is not connected to an identity provider", orgId)
+	}
+
+	return federationSettingsId, nil
+}
+
+// listRoleMappings returns the organization's federation settings ID and its role mappings. Organizations that
+// are not connected to an identity provider have no role mappings. Only Organization Owners can read federation
+// settings, so an API key without that role is treated as if the organization were not federated.
+func listRoleMappings(ctx context.Context, client *admin.APIClient, orgId string) (string, []admin.AuthFederationRoleMapping, error) {
+	federationSettingsId, err := getFederationSettingsId(ctx, client, orgId)
+	if status.Code(err) == codes.PermissionDenied {
+		ctxzap.Extract(ctx).Warn(
+			"baton-mongodb-atlas: not allowed to read federation settings, skipping role mappings",
+			zap.String("orgId", orgId),
+			zap.Error(err),
+		)
+		return "", nil, nil
+	}
+	if err != nil || federationSettingsId == "" {
+		return "", nil, err
+	}
+
+	mappings, resp, err := client.FederatedAuthenticationApi.ListRoleMappings(ctx, federationSettingsId, orgId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		err = parseToUHttpError(resp, err)
+		switch status.Code(err) {
+		case codes.NotFound:
+			return federationSettingsId, nil, nil
+		case codes.PermissionDenied:
+			ctxzap.Extract(ctx).Warn(
+				"baton-mongodb-atlas: not allowed to list role mappings, skipping them",
+				zap.String("orgId", orgId),
+				zap.Error(err),
+			)
+			return federationSettingsId, nil, nil
+		}
+		return "", nil, fmt.Errorf("baton-mongodb-atlas: failed to list role mappings: %w", err)
+	}
+
+	return federationSettingsId, mappings.GetResults(), nil
+}
+
+// organizationRoleMappings is the federation settings ID and the role mappings of an organization.
+type organizationRoleMappings struct {
+	federationSettingsId string
+	mappings             []admin.AuthFederationRoleMapping
+}
+
+// roleMappingCache shares the role mappings of each organization between the organization, project and role mapping
+// builders, so that they are read once per organization rather than once per project.
+type roleMappingCache struct {
+	client *admin.APIClient
+	cache  *keyedCache[organizationRoleMappings]
+}
+
+func newRoleMappingCache(client *admin.APIClient) *roleMappingCache {
+	return &roleMappingCache{
+		client: client,
+		cache:  newKeyedCache[organizationRoleMappings](cacheTTL),
+	}
+}
+
+// list returns the organization's federation settings ID and its role mappings, reading them on first use.
+func (c *roleMappingCache) list(ctx context.Context, orgId string) (string, []admin.AuthFederationRoleMapping, error) {
+	roleMappings, err := c.cache.get(orgId, func() (organizationRoleMappings, error) {
+		federationSettingsId, mappings, err := listRoleMappings(ctx, c.client, orgId)
+		return organizationRoleMappings{federationSettingsId: federationSettingsId, mappings: mappings}, err
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return roleMappings.federationSettingsId, roleMappings.mappings, nil
+}
+
+// invalidate drops the organization's cached role mappings after they are changed.
+func (c *roleMappingCache) invalidate(orgId string) {
+	c.cache.invalidate(orgId)
+}
+
+// roleMappingGrants emits a grant on resource for every role a mapping assigns in the organization, when groupId is
+// empty, or in the project identified by groupId.
+func roleMappingGrants(
+	resource *v2.Resource,
+	orgId string,
+	groupId string,
+	mappings []admin.AuthFederationRoleMapping,
+	entitlements map[string]string,
+) []*v2.Grant {
+	var rv []*v2.Grant
+	for _, mapping := range mappings {
+		mappingId := newRoleMappingResourceId(orgId, mapping.GetId())
+
+		for _, assignment := range mapping.GetRoleAssignments() {
+			if assignment.GetGroupId() != groupId || (groupId == "" && assignment.GetOrgId() != orgId) {
+				continue
+			}
+
+			if entitlementTarget, ok := entitlements[assignment.GetRole()]; ok {
+				rv = append(rv, grant.NewGrant(resource, entitlementTarget, mappingId))
+			}
+		}
+	}
+
+	return rv
+}
+
+func isSameRoleAssignment(a, b admin.ConnectedOrgConfigRoleAssignment) bool {
+	return a.GetOrgId() == b.GetOrgId() && a.GetGroupId() == b.GetGroupId() && a.GetRole() == b.GetRole()
+}
+
+// getRoleMapping returns a role mapping and the ID of the federation it belongs to.
+func getRoleMapping(ctx context.Context, client *admin.APIClient, orgId, mappingId string) (string, *admin.AuthFederationRoleMapping, error) {
+	federationSettingsId, err := requireFederationSettingsId(ctx, client, orgId)
+	if err != nil {
+		return "", nil, err
+	}
+
+	mapping, resp, err := client.FederatedAuthenticationApi.GetRoleMapping(ctx, federationSettingsId, mappingId, orgId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return "", nil, fmt.Errorf("baton-mongodb-atlas: failed to get role mapping: %w", parseToUHttpError(resp, err))
+	}
+
+	return federationSettingsId, mapping, nil
+}
+
+func updateRoleMappingAssignments(
+	ctx context.Context,
+	client *admin.APIClient,
+	federationSettingsId string,
+	orgId string,
+	mapping *admin.AuthFederationRoleMapping,
+	roleAssignments []admin.ConnectedOrgConfigRoleAssignment,
+) error {
+	_, resp, err := client.FederatedAuthenticationApi.UpdateRoleMapping(
+		ctx,
+		federationSettingsId,
+		mapping.GetId(),
+		orgId,
+		&admin.AuthFederationRoleMapping{
+			ExternalGroupName: mapping.GetExternalGroupName(),
+			RoleAssignments:   &roleAssignments,
+		},
+	).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return fmt.Errorf("baton-mongodb-atlas: failed to update role mapping: %w", parseToUHttpError(resp, err))
+	}
+
+	return nil
+}
+
+// grantRoleMappingRole adds an organization or project role assignment to the role mapping identified by mappingResourceId.
+func grantRoleMappingRole(
+	ctx context.Context,
+	roleMappings *roleMappingCache,
+	mappingResourceId string,
+	assignment admin.ConnectedOrgConfigRoleAssignment,
+) (annotations.Annotations, error) {
+	orgId, mappingId, err := parseRoleMappingResourceId(mappingResourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	client := roleMappings.client
+	defer roleMappings.invalidate(orgId)
+
+	federationSettingsId, mapping, err := getRoleMapping(ctx, client, orgId, mappingId)
+	if err != nil {
+		return nil, err
+	}
+
+	roleAssignments := mapping.GetRoleAssignments()
+	if slices.ContainsFunc(roleAssignments, func(a admin.ConnectedOrgConfigRoleAssignment) bool {
+		return isSameRoleAssignment(a, assignment)
+	}) {
+		return annotations.New(&v2.GrantAlreadyExists{}), nil
+	}
+
+	if err := updateRoleMappingAssignments(ctx, client, federationSettingsId, orgId, mapping, append(roleAssignments, assignment)); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// revokeRoleMappingRole removes an organization or project role assignment from a role mapping. The mapping is deleted
+// once it assigns no roles at all, since Atlas does not allow a role mapping without an organization role.
+func revokeRoleMappingRole(
+	ctx context.Context,
+	roleMappings *roleMappingCache,
+	mappingResourceId string,
+	assignment admin.ConnectedOrgConfigRoleAssignment,
+) (annotations.Annotations, error) {
+	orgId, mappingId, err := parseRoleMappingResourceId(mappingResourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	client := roleMappings.client
+	defer roleMappings.invalidate(orgId)
+
+	federationSettingsId, mapping, err := getRoleMapping(ctx, client, orgId, mappingId)
+	if err != nil {
+		return nil, err
+	}
+
+	roleAssignments := mapping.GetRoleAssignments()
+	newRoleAssignments := slices.DeleteFunc(slices.Clone(roleAssignments), func(a admin.ConnectedOrgConfigRoleAssignment) bool {
+		return isSameRoleAssignment(a, assignment)
+	})
+
+	if len(newRoleAssignments) == len(roleAssignments) {
+		return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+	}
+
+	if len(newRoleAssignments) == 0 {
+		resp, err := client.FederatedAuthenticationApi.DeleteRoleMapping(ctx, federationSettingsId, mappingId, orgId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			return nil, fmt.Errorf("baton-mongodb-atlas: failed to delete role mapping: %w", parseToUHttpError(resp, err))
+		}
+
+		return nil, nil
+	}
+
+	if !slices.ContainsFunc(newRoleAssignments, func(a admin.ConnectedOrgConfigRoleAssignment) bool {
+		return a.GetOrgId() != ""
+	}) {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"baton-mongodb-atlas: cannot revoke the last organization role %s from role mapping %s; revoke its project roles first",
+			assignment.GetRole(),
+			mapping.GetExternalGroupName(),
+		)
+	}
+
+	if err := updateRoleMappingAssignments(ctx, client, federationSettingsId, orgId, mapping, newRoleAssignments); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,98 @@
+package connector
+
+import (
+	"testing"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/atlas-sdk/v20250312006/admin"
+)
+
+func TestRoleMappings(t *testing.T) {
+	orgResource := &v2.Resource{Id: &v2.ResourceId{ResourceType: organizationResourceType.Id, Resource: "org"}}
+	projectResource := &v2.Resource{
+		Id:               &v2.ResourceId{ResourceType: projectResourceType.Id, Resource: "project"},
+		ParentResourceId: orgResource.Id,
+	}
+
+	mappings := []admin.AuthFederationRoleMapping{
+		{
+			Id:                admin.PtrString("admins"),
+			ExternalGroupName: "atlas-admins",
+			RoleAssignments: &[]admin.ConnectedOrgConfigRoleAssignment{
+				{OrgId: admin.PtrString("org"), Role: admin.PtrString("ORG_OWNER")},
+				{GroupId: admin.PtrString("project"), Role: admin.PtrString("GROUP_OWNER")},
+			},
+		},
+		{
+			Id:                admin.PtrString("developers"),
+			ExternalGroupName: "atlas-developers",
+			RoleAssignments: &[]admin.ConnectedOrgConfigRoleAssignment{
+				{OrgId: admin.PtrString("org"), Role: admin.PtrString("ORG_MEMBER")},
+				{OrgId: admin.PtrString("other-org"), Role: admin.PtrString("ORG_OWNER")},
+				{GroupId: admin.PtrString("project"), Role: admin.PtrString("GROUP_READ_ONLY")},
+				{GroupId: admin.PtrString("other-project"), Role: admin.PtrString("GROUP_OWNER")},
+				{GroupId: admin.PtrString("project"), Role: admin.PtrString("GROUP_UNKNOWN_ROLE")},
+			},
+		},
+	}
+
+	type expectedGrant struct {
+		entitlement string
+		principal   string
+	}
+
+	tests := []struct {
+		name         string
+		resource     *v2.Resource
+		groupId      string
+		mappings     []admin.AuthFederationRoleMapping
+		entitlements map[string]string
+		expected     []expectedGrant
+	}{
+		{
+			name:         "no role mappings",
+			resource:     orgResource,
+			entitlements: userRolesOrganizationEntitlementMap,
+		},
+		{
+			name:         "organization roles",
+			resource:     orgResource,
+			mappings:     mappings,
+			entitlements: userRolesOrganizationEntitlementMap,
+			expected: []expectedGrant{
+				{entitlement: entitlement.NewEntitlementID(orgResource, ownerEntitlement), principal: "org:admins"},
+				{entitlement: entitlement.NewEntitlementID(orgResource, memberEntitlement), principal: "org:developers"},
+			},
+		},
+		{
+			name:         "project roles",
+			resource:     projectResource,
+			groupId:      "project",
+			mappings:     mappings,
+			entitlements: userRolesProjectEntitlementMap,
+			expected: []expectedGrant{
+				{entitlement: entitlement.NewEntitlementID(projectResource, ownerEntitlement), principal: "org:admins"},
+				{entitlement: entitlement.NewEntitlementID(projectResource, readOnlyEntitlement), principal: "org:developers"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grants := roleMappingGrants(tt.resource, "org", tt.groupId, tt.mappings, tt.entitlements)
+
+			var actual []expectedGrant
+			for _, g := range grants {
+				assert.Equal(t, tt.resource.Id, g.GetEntitlement().GetResource().GetId())
+				assert.Equal(t, roleMappingResourceType.Id, g.GetPrincipal().GetId().GetResourceType())
+				actual = append(actual, expectedGrant{
+					entitlement: g.GetEntitlement().GetId(),
+					principal:   g.GetPrincipal().GetId().GetResource(),
+				})
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
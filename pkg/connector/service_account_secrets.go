@@ -0,0 +1,141 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"go.mongodb.org/atlas-sdk/v20250312006/admin"
+)
+
+// serviceAccountSecretDetail labels the client secrets of organization service accounts.
+const serviceAccountSecretDetail = "mongodb.service_account_secret" //nolint:gosec // G101 false positive: axis-2 detail label, not a credential.
+
+var _ connectorbuilder.ResourceDeleterV2 = (*serviceAccountSecretBuilder)(nil)
+
+type serviceAccountSecretBuilder struct {
+	resourceType *v2.ResourceType
+	client       *admin.APIClient
+}
+
+func (o *serviceAccountSecretBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return serviceAccountSecretResourceType
+}
+
+func newServiceAccountSecretResourceId(orgId, clientId, secretId string) *v2.ResourceId {
+	return &v2.ResourceId{
+		ResourceType: serviceAccountSecretResourceType.Id,
+		Resource:     fmt.Sprintf("%s:%s:%s", orgId, clientId, secretId),
+	}
+}
+
+func parseServiceAccountSecretResourceId(resourceId string) (string, string, string, error) {
+	parts := strings.Split(resourceId, ":")
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf("invalid service account secret resource id: %s", resourceId)
+	}
+
+	return parts[0], parts[1], parts[2], nil
+}
+
+// newServiceAccountSecretResource builds a secret resource owned by the service account identified by serviceAccountId.
+func newServiceAccountSecretResource(serviceAccountId *v2.ResourceId, secret admin.ServiceAccountSecret) (*v2.Resource, error) {
+	orgId, clientId, err := parseServiceAccountResourceId(serviceAccountId.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	displayName := secret.GetMaskedSecretValue()
+	if displayName == "" {
+		displayName = secret.GetId()
+	}
+
+	secretTraits := []rs.SecretTraitOption{
+		rs.WithSecretType(v2.SecretTrait_CREDENTIAL_TYPE_STATIC_SECRET),
+		rs.WithSecretDetail(serviceAccountSecretDetail),
+		rs.WithSecretIdentityID(serviceAccountId),
+		rs.WithSecretCreatedAt(secret.GetCreatedAt()),
+		rs.WithSecretExpiresAt(secret.GetExpiresAt()),
+	}
+	if secret.HasLastUsedAt() {
+		secretTraits = append(secretTraits, rs.WithSecretLastUsedAt(secret.GetLastUsedAt()))
+	}
+
+	resource, err := rs.NewSecretResource(
+		displayName,
+		serviceAccountSecretResourceType,
+		newServiceAccountSecretResourceId(orgId, clientId, secret.GetId()).Resource,
+		secretTraits,
+		rs.WithParentResourceID(serviceAccountId),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+func newServiceAccountSecretBuilder(client *admin.APIClient) *serviceAccountSecretBuilder {
+	return &serviceAccountSecretBuilder{
+		resourceType: serviceAccountSecretResourceType,
+		client:       client,
+	}
+}
+
+// List returns the client secrets of a service account. Atlas returns the secrets inline with the service account.
+func (o *serviceAccountSecretBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, _ rs.SyncOpAttrs) ([]*v2.Resource, *rs.SyncOpResults, error) {
+	if parentResourceID == nil || parentResourceID.ResourceType != serviceAccountResourceType.Id {
+		return nil, nil, nil
+	}
+
+	orgId, clientId, err := parseServiceAccountResourceId(parentResourceID.Resource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serviceAccount, resp, err := o.client.ServiceAccountsApi.GetServiceAccount(ctx, orgId, clientId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get service account: %w", parseToUHttpError(resp, err))
+	}
+
+	var resources []*v2.Resource
+	for _, secret := range serviceAccount.GetSecrets() {
+		resource, err := newServiceAccountSecretResource(parentResourceID, secret)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create service account secret resource: %w", err)
+		}
+
+		resources = append(resources, resource)
+	}
+
+	return resources, nil, nil
+}
+
+// Entitlements always returns an empty slice; secrets are credentials, not grantable resources.
+func (o *serviceAccountSecretBuilder) Entitlements(_ context.Context, _ *v2.Resource, _ rs.SyncOpAttrs) ([]*v2.Entitlement, *rs.SyncOpResults, error) {
+	return nil, nil, nil
+}
+
+// Grants always returns an empty slice; secrets are credentials, not grantable resources.
+func (o *serviceAccountSecretBuilder) Grants(_ context.Context, _ *v2.Resource, _ rs.SyncOpAttrs) ([]*v2.Grant, *rs.SyncOpResults, error) {
+	return nil, nil, nil
+}
+
+// Delete revokes a service account client secret.
+func (o *serviceAccountSecretBuilder) Delete(ctx context.Context, resourceId *v2.ResourceId, _ *v2.ResourceId) (annotations.Annotations, error) {
+	orgId, clientId, secretId, err := parseServiceAccountSecretResourceId(resourceId.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.client.ServiceAccountsApi.DeleteServiceAccountSecret(ctx, clientId, secretId, orgId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete service account secret: %w", parseToUHttpError(resp, err))
+	}
+
+	return nil, nil
+}
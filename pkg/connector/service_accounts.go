@@ -0,0 +1,371 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/conductorone/baton-sdk/pkg/uhttp"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.mongodb.org/atlas-sdk/v20250312006/admin"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// Atlas bounds client secret lifetimes by organization settings; these are the documented defaults.
+	serviceAccountSecretMinExpiry     = 8 * time.Hour
+	serviceAccountSecretMaxExpiry     = 365 * 24 * time.Hour
+	serviceAccountSecretDefaultExpiry = 90 * 24 * time.Hour
+
+	// Atlas allows at most two active secrets per service account.
+	serviceAccountMaxSecrets = 2
+)
+
+var (
+	_ connectorbuilder.CredentialIssuerV2       = (*serviceAccountBuilder)(nil)
+	_ connectorbuilder.CredentialManagerLimited = (*serviceAccountBuilder)(nil)
+)
+
+type serviceAccountBuilder struct {
+	resourceType *v2.ResourceType
+	client       *admin.APIClient
+}
+
+func (o *serviceAccountBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return serviceAccountResourceType
+}
+
+// newServiceAccountResourceId builds the service account resource ID, which is scoped by organization
+// in the same way as team IDs so that operations that only receive the ID can still address the API.
+func newServiceAccountResourceId(orgId string, clientId string) *v2.ResourceId {
+	return &v2.ResourceId{
+		ResourceType: serviceAccountResourceType.Id,
+		Resource:     fmt.Sprintf("%s:%s", orgId, clientId),
+	}
+}
+
+func parseServiceAccountResourceId(resourceId string) (string, string, error) {
+	parts := strings.Split(resourceId, ":")
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid service account resource id: %s", resourceId)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+func newServiceAccountResource(organizationId *v2.ResourceId, serviceAccount admin.OrgServiceAccount) (*v2.Resource, error) {
+	clientId := serviceAccount.GetClientId()
+
+	displayName := serviceAccount.GetName()
+	if displayName == "" {
+		displayName = clientId
+	}
+
+	profile := map[string]interface{}{
+		"client_id":       clientId,
+		"name":            serviceAccount.GetName(),
+		"description":     serviceAccount.GetDescription(),
+		"organization_id": organizationId.Resource,
+		"roles":           strings.Join(serviceAccount.GetRoles(), ", "),
+	}
+
+	userTraits := []rs.UserTraitOption{
+		rs.WithUserProfile(profile),
+		rs.WithUserLogin(clientId),
+		rs.WithStatus(v2.UserTrait_Status_STATUS_ENABLED),
+		rs.WithAccountType(v2.UserTrait_ACCOUNT_TYPE_SERVICE),
+	}
+
+	if serviceAccount.HasCreatedAt() {
+		userTraits = append(userTraits, rs.WithCreatedAt(serviceAccount.GetCreatedAt()))
+	}
+
+	resourceOpts := []rs.ResourceOption{
+		rs.WithParentResourceID(organizationId),
+		rs.WithAnnotation(&v2.ChildResourceType{ResourceTypeId: serviceAccountSecretResourceType.Id}),
+	}
+	if description := serviceAccount.GetDescription(); description != "" {
+		resourceOpts = append(resourceOpts, rs.WithDescription(description))
+	}
+
+	resource, err := rs.NewUserResource(
+		displayName,
+		serviceAccountResourceType,
+		newServiceAccountResourceId(organizationId.Resource, clientId).Resource,
+		userTraits,
+		resourceOpts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+func newServiceAccountBuilder(client *admin.APIClient) *serviceAccountBuilder {
+	return &serviceAccountBuilder{
+		resourceType: serviceAccountResourceType,
+		client:       client,
+	}
+}
+
+// List returns the organization's OAuth service accounts as user resources.
+func (o *serviceAccountBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, opts rs.SyncOpAttrs) ([]*v2.Resource, *rs.SyncOpResults, error) {
+	if parentResourceID == nil {
+		return nil, nil, nil
+	}
+
+	bag, page, err := parsePageToken(opts.PageToken.Token, &v2.ResourceId{ResourceType: o.resourceType.Id})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serviceAccounts, resp, err := o.client.ServiceAccountsApi.ListServiceAccounts(
+		ctx,
+		parentResourceID.GetResource(),
+	).PageNum(page).ItemsPerPage(resourcePageSize).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list service accounts: %w", parseToUHttpError(resp, err))
+	}
+
+	if serviceAccounts == nil || serviceAccounts.Results == nil {
+		return nil, nil, nil
+	}
+
+	var resources []*v2.Resource
+	for _, serviceAccount := range *serviceAccounts.Results {
+		resource, err := newServiceAccountResource(parentResourceID, serviceAccount)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create service account resource: %w", err)
+		}
+
+		resources = append(resources, resource)
+	}
+
+	if isLastPage(len(*serviceAccounts.Results), resourcePageSize) {
+		return resources, nil, nil
+	}
+
+	nextPage, err := getPageTokenFromPage(bag, page+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resources, &rs.SyncOpResults{NextPageToken: nextPage}, nil
+}
+
+// Entitlements always returns an empty slice for service accounts.
+func (o *serviceAccountBuilder) Entitlements(_ context.Context, _ *v2.Resource, _ rs.SyncOpAttrs) ([]*v2.Entitlement, *rs.SyncOpResults, error) {
+	return nil, nil, nil
+}
+
+// Grants always returns an empty slice; service account roles are emitted by the organization and project builders.
+func (o *serviceAccountBuilder) Grants(_ context.Context, _ *v2.Resource, _ rs.SyncOpAttrs) ([]*v2.Grant, *rs.SyncOpResults, error) {
+	return nil, nil, nil
+}
+
+// IssueCapabilityDetails advertises client secret issuance. Secrets are synced as service_account_secret resources.
+func (o *serviceAccountBuilder) IssueCapabilityDetails(_ context.Context) (*v2.CredentialDetailsCredentialIssue, annotations.Annotations, error) {
+	return &v2.CredentialDetailsCredentialIssue{
+		Options: []*v2.CredentialIssueOptionDescriptor{
+			{
+				Option: v2.CapabilityDetailCredentialOption_CAPABILITY_DETAIL_CREDENTIAL_OPTION_CLIENT_SECRET,
+				Expiry: &v2.IssuanceExpiryCapability{
+					Min: durationpb.New(serviceAccountSecretMinExpiry),
+					Max: durationpb.New(serviceAccountSecretMaxExpiry),
+				},
+				ResourceMode:         v2.CredentialResourceMode_CREDENTIAL_RESOURCE_MODE_DISCOVERABLE,
+				SecretResourceTypeId: serviceAccountSecretResourceType.Id,
+			},
+		},
+		PreferredOption: v2.CapabilityDetailCredentialOption_CAPABILITY_DETAIL_CREDENTIAL_OPTION_CLIENT_SECRET,
+	}, nil, nil
+}
+
+// Issue creates a new client secret for the service account without revoking the existing ones.
+func (o *serviceAccountBuilder) Issue(ctx context.Context, input *connectorbuilder.CredentialIssueInput) (*connectorbuilder.CredentialIssueOutput, error) {
+	if input.CredentialOptions.GetClientSecret() == nil {
+		return nil, uhttp.WrapErrors(codes.InvalidArgument, "mongo-db-connector: only client secrets can be issued for service accounts", errors.New("unsupported credential option"))
+	}
+
+	orgId, clientId, err := parseServiceAccountResourceId(input.IdentityID.GetResource())
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAfterHours, err := serviceAccountSecretExpiresAfterHours(input.ExpiresAt, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := o.createSecret(ctx, orgId, clientId, expiresAfterHours)
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := newServiceAccountSecretResource(input.IdentityID, *secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create service account secret resource: %w", err)
+	}
+
+	return &connectorbuilder.CredentialIssueOutput{
+		Secret:        resource,
+		PlaintextData: newServiceAccountSecretPlaintextData(secret),
+		ResourceMode:  v2.CredentialResourceMode_CREDENTIAL_RESOURCE_MODE_DISCOVERABLE,
+	}, nil
+}
+
+// RotateCapabilityDetails advertises rotation without a caller-supplied password; Atlas generates the client secret.
+func (o *serviceAccountBuilder) RotateCapabilityDetails(_ context.Context) (*v2.CredentialDetailsCredentialRotation, annotations.Annotations, error) {
+	return &v2.CredentialDetailsCredentialRotation{
+		SupportedCredentialOptions: []v2.CapabilityDetailCredentialOption{
+			v2.CapabilityDetailCredentialOption_CAPABILITY_DETAIL_CREDENTIAL_OPTION_NO_PASSWORD,
+		},
+		PreferredCredentialOption: v2.CapabilityDetailCredentialOption_CAPABILITY_DETAIL_CREDENTIAL_OPTION_NO_PASSWORD,
+	}, nil, nil
+}
+
+// Rotate replaces every client secret of the service account with a single new one.
+// The new secret keeps the lifetime of the most recent existing secret.
+func (o *serviceAccountBuilder) Rotate(
+	ctx context.Context,
+	resourceId *v2.ResourceId,
+	_ *v2.LocalCredentialOptions,
+) ([]*v2.PlaintextData, annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	orgId, clientId, err := parseServiceAccountResourceId(resourceId.GetResource())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serviceAccount, resp, err := o.client.ServiceAccountsApi.GetServiceAccount(ctx, orgId, clientId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get service account: %w", parseToUHttpError(resp, err))
+	}
+
+	oldSecrets := serviceAccount.GetSecrets()
+	slices.SortFunc(oldSecrets, func(a, b admin.ServiceAccountSecret) int {
+		return a.GetCreatedAt().Compare(b.GetCreatedAt())
+	})
+
+	expiresAfterHours := int(serviceAccountSecretDefaultExpiry.Hours())
+	if len(oldSecrets) > 0 {
+		newest := oldSecrets[len(oldSecrets)-1]
+		lifetime := newest.GetExpiresAt().Sub(newest.GetCreatedAt())
+		expiresAfterHours = int(min(max(lifetime, serviceAccountSecretMinExpiry), serviceAccountSecretMaxExpiry).Hours())
+	}
+
+	secret, err := o.createSecret(ctx, orgId, clientId, expiresAfterHours)
+	if err != nil {
+		if len(oldSecrets) < serviceAccountMaxSecrets || !isSecretLimitError(err) {
+			return nil, nil, err
+		}
+
+		// The service account is at its secret limit, so make room for the new secret by revoking the oldest one.
+		oldestId := oldSecrets[0].GetId()
+		if err := o.deleteSecret(ctx, orgId, clientId, oldestId); err != nil {
+			return nil, nil, err
+		}
+		oldSecrets = oldSecrets[1:]
+
+		secret, err = o.createSecret(ctx, orgId, clientId, expiresAfterHours)
+		if err != nil {
+			return nil, nil, fmt.Errorf("oldest service account secret %s was already revoked to make room for the new secret: %w", oldestId, err)
+		}
+	}
+
+	for _, oldSecret := range oldSecrets {
+		if err := o.deleteSecret(ctx, orgId, clientId, oldSecret.GetId()); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	l.Debug(
+		"rotated service account secret",
+		zap.String("org_id", orgId),
+		zap.String("client_id", clientId),
+		zap.String("secret_id", secret.GetId()),
+	)
+
+	return newServiceAccountSecretPlaintextData(secret), nil, nil
+}
+
+// isSecretLimitError reports whether creating a client secret failed because Atlas rejected the request, which is
+// how it reports that the service account already has as many secrets as it allows.
+func isSecretLimitError(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.AlreadyExists:
+		return true
+	default:
+		return false
+	}
+}
+
+func (o *serviceAccountBuilder) createSecret(ctx context.Context, orgId, clientId string, expiresAfterHours int) (*admin.ServiceAccountSecret, error) {
+	secret, resp, err := o.client.ServiceAccountsApi.CreateServiceAccountSecret(
+		ctx,
+		orgId,
+		clientId,
+		admin.NewServiceAccountSecretRequest(expiresAfterHours),
+	).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, fmt.Errorf("failed to create service account secret: %w", parseToUHttpError(resp, err))
+	}
+
+	if secret.GetSecret() == "" {
+		return nil, fmt.Errorf("service account secret %s was created without a secret value", secret.GetId())
+	}
+
+	return secret, nil
+}
+
+func (o *serviceAccountBuilder) deleteSecret(ctx context.Context, orgId, clientId, secretId string) error {
+	resp, err := o.client.ServiceAccountsApi.DeleteServiceAccountSecret(ctx, clientId, secretId, orgId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return fmt.Errorf("failed to delete service account secret: %w", parseToUHttpError(resp, err))
+	}
+
+	return nil
+}
+
+// serviceAccountSecretExpiresAfterHours converts the requested expiry into the whole number of hours Atlas expects,
+// rounding down so that the secret never outlives the requested time.
+func serviceAccountSecretExpiresAfterHours(expiresAt *timestamppb.Timestamp, now time.Time) (int, error) {
+	if expiresAt == nil {
+		return int(serviceAccountSecretDefaultExpiry.Hours()), nil
+	}
+
+	lifetime := min(expiresAt.AsTime().Sub(now), serviceAccountSecretMaxExpiry)
+	if lifetime < serviceAccountSecretMinExpiry {
+		return 0, uhttp.WrapErrors(
+			codes.InvalidArgument,
+			"mongo-db-connector: service account secrets must expire at least 8 hours from now",
+			fmt.Errorf("requested expiry %s is too soon", expiresAt.AsTime().Format(time.RFC3339)),
+		)
+	}
+
+	return int(lifetime / time.Hour), nil
+}
+
+func newServiceAccountSecretPlaintextData(secret *admin.ServiceAccountSecret) []*v2.PlaintextData {
+	return []*v2.PlaintextData{
+		{
+			Name:        "client_secret",
+			Description: "The client secret for the service account",
+			Schema:      "text/plain",
+			Bytes:       []byte(secret.GetSecret()),
+		},
+	}
+}
@@ -35,6 +35,14 @@ func parseTeamResourceId(resourceId string) (string, string, error) {
 	return parts[0], parts[1], nil
 }
 
+// newTeamResourceId builds the team resource ID, which is scoped by organization.
+func newTeamResourceId(orgId string, teamId string) *v2.ResourceId {
+	return &v2.ResourceId{
+		ResourceType: teamResourceType.Id,
+		Resource:     fmt.Sprintf("%s:%s", orgId, teamId),
+	}
+}
+
 func newTeamResource(_ context.Context, organizationId *v2.ResourceId, team admin.TeamResponse) (*v2.Resource, error) {
 	teamId := *team.Id
 	teamName := *team.Name
@@ -52,11 +60,11 @@ func newTeamResource(_ context.Context, organizationId *v2.ResourceId, team admi
 		rs.WithGroupProfile(profile),
 	}
 
-	resourceId := fmt.Sprintf("%s:%s", organizationId.Resource, teamId)
+	resourceId := newTeamResourceId(organizationId.Resource, teamId)
 	resource, err := rs.NewGroupResource(
 		teamName,
 		teamResourceType,
-		resourceId,
+		resourceId.Resource,
 		teamTraits,
 		rs.WithParentResourceID(organizationId),
 	)
@@ -118,6 +126,27 @@ func (o *teamBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId,
 	return resources, &rs.SyncOpResults{NextPageToken: nextPage}, nil
 }
 
+// Get returns a single team, so that it can be re-synced when its organization's activity feed reports a change.
+func (o *teamBuilder) Get(ctx context.Context, resourceId *v2.ResourceId, _ *v2.ResourceId) (*v2.Resource, annotations.Annotations, error) {
+	orgId, teamId, err := parseTeamResourceId(resourceId.Resource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	team, resp, err := o.client.TeamsApi.GetTeamById(ctx, orgId, teamId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get team: %w", parseToUHttpError(resp, err))
+	}
+
+	organizationId := &v2.ResourceId{ResourceType: organizationResourceType.Id, Resource: orgId}
+	resource, err := newTeamResource(ctx, organizationId, *team)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create team resource: %w", err)
+	}
+
+	return resource, nil, nil
+}
+
 // Entitlements always returns an empty slice for users.
 func (o *teamBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ rs.SyncOpAttrs) ([]*v2.Entitlement, *rs.SyncOpResults, error) {
 	var rv []*v2.Entitlement
@@ -0,0 +1,181 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	config "github.com/conductorone/baton-sdk/pb/c1/config/v1"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/actions"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
+	"github.com/conductorone/baton-sdk/pkg/uhttp"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.mongodb.org/atlas-sdk/v20250312006/admin"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	resendInvitationActionName = "resend_invitation"
+
+	// invitationRenewalWindow is how close to its expiry an invitation must be before it can be re-sent.
+	invitationRenewalWindow = 7 * 24 * time.Hour
+)
+
+var _ connectorbuilder.ResourceActionProvider = (*userBuilder)(nil)
+
+// ResourceActions registers the user actions.
+func (o *userBuilder) ResourceActions(ctx context.Context, registry actions.ActionRegistry) error {
+	schema := v2.BatonActionSchema_builder{
+		Name:           resendInvitationActionName,
+		DisplayName:    "Re-send organization invitation",
+		Description:    "Re-issues the expired or about-to-expire organization invitation of a pending user, keeping the roles and teams the user was invited with.",
+		ResourceTypeId: userResourceType.Id,
+		Arguments: []*config.Field{
+			config.Field_builder{
+				Name:        "resource_id",
+				DisplayName: "User",
+				Description: "The pending user to re-invite.",
+				IsRequired:  true,
+				ResourceIdField: config.ResourceIdField_builder{
+					Rules: config.ResourceIDRules_builder{
+						AllowedResourceTypeIds: []string{userResourceType.Id},
+					}.Build(),
+				}.Build(),
+			}.Build(),
+		},
+		ReturnTypes: []*config.Field{
+			config.Field_builder{Name: "success", BoolField: &config.BoolField{}}.Build(),
+			config.Field_builder{Name: "invitation_id", StringField: &config.StringField{}}.Build(),
+			config.Field_builder{Name: "invitation_expires_at", StringField: &config.StringField{}}.Build(),
+		},
+	}.Build()
+
+	return registry.Register(ctx, schema, o.resendInvitation)
+}
+
+// resendInvitation refreshes the expired or about-to-expire invitation of a pending user, or creates a new one when
+// the original invitation is gone, using the roles and teams recorded on the pending organization user. Invitations
+// with more than invitationRenewalWindow left are left alone.
+func (o *userBuilder) resendInvitation(ctx context.Context, args *structpb.Struct) (*structpb.Struct, annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	resourceId, err := actions.RequireResourceIDArg(args, "resource_id")
+	if err != nil {
+		return nil, nil, uhttp.WrapErrors(codes.InvalidArgument, "mongo-db-connector: resource_id is required", err)
+	}
+	userId := resourceId.GetResource()
+
+	var user *admin.OrgUserResponse
+	orgId, err := findOrganizationId(ctx, o.client, func(orgId string) (bool, error) {
+		orgUser, resp, err := o.client.MongoDBCloudUsersApi.GetOrganizationUser(ctx, orgId, userId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			err = parseToUHttpError(resp, err)
+			if status.Code(err) == codes.NotFound {
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to get organization user: %w", err)
+		}
+
+		user = orgUser
+		return true, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if user.GetOrgMembershipStatus() != userStatusPending {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "mongo-db-connector: user %s has already joined the organization", user.GetUsername())
+	}
+
+	orgRoles := user.Roles.GetOrgRoles()
+	teamIds := user.GetTeamIds()
+
+	var groupRoleAssignments []admin.OrganizationInvitationGroupRoleAssignmentsRequest
+	for _, assignment := range user.Roles.GetGroupRoleAssignments() {
+		groupRoleAssignments = append(groupRoleAssignments, admin.OrganizationInvitationGroupRoleAssignmentsRequest{
+			GroupId: assignment.GroupId,
+			Roles:   assignment.GroupRoles,
+		})
+	}
+
+	invitations, resp, err := o.client.OrganizationsApi.ListOrganizationInvitations(ctx, orgId).Username(user.GetUsername()).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list organization invitations: %w", parseToUHttpError(resp, err))
+	}
+
+	if len(invitations) > 0 && !invitationNeedsRenewal(invitations[0], time.Now()) {
+		return nil, nil, status.Errorf(
+			codes.FailedPrecondition,
+			"mongo-db-connector: the invitation of user %s is not about to expire; it expires at %s",
+			user.GetUsername(),
+			invitations[0].GetExpiresAt().Format(time.RFC3339),
+		)
+	}
+
+	var invitation *admin.OrganizationInvitation
+	if len(invitations) > 0 {
+		invitation, resp, err = o.client.OrganizationsApi.UpdateOrganizationInvitationById(
+			ctx,
+			orgId,
+			invitations[0].GetId(),
+			&admin.OrganizationInvitationUpdateRequest{
+				Roles:                &orgRoles,
+				TeamIds:              &teamIds,
+				GroupRoleAssignments: &groupRoleAssignments,
+			},
+		).Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to update organization invitation: %w", parseToUHttpError(resp, err))
+		}
+	} else {
+		username := user.GetUsername()
+		invitation, resp, err = o.client.OrganizationsApi.CreateOrganizationInvitation(
+			ctx,
+			orgId,
+			&admin.OrganizationInvitationRequest{
+				Username:             &username,
+				Roles:                &orgRoles,
+				TeamIds:              &teamIds,
+				GroupRoleAssignments: &groupRoleAssignments,
+			},
+		).Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create organization invitation: %w", parseToUHttpError(resp, err))
+		}
+	}
+
+	o.invitations.invalidate(orgId)
+
+	l.Info(
+		"baton-mongodb-atlas: re-sent organization invitation",
+		zap.String("orgId", orgId),
+		zap.String("userId", userId),
+		zap.String("invitationId", invitation.GetId()),
+	)
+
+	var expiresAt string
+	if invitation.HasExpiresAt() {
+		expiresAt = invitation.GetExpiresAt().Format(time.RFC3339)
+	}
+
+	return actions.NewReturnValues(
+		true,
+		actions.NewStringReturnField("invitation_id", invitation.GetId()),
+		actions.NewStringReturnField("invitation_expires_at", expiresAt),
+	), nil, nil
+}
+
+// invitationNeedsRenewal reports whether an invitation has expired or expires within invitationRenewalWindow.
+// Invitations without an expiry date are treated as expired.
+func invitationNeedsRenewal(invitation admin.OrganizationInvitation, now time.Time) bool {
+	if !invitation.HasExpiresAt() {
+		return true
+	}
+
+	return invitation.GetExpiresAt().Sub(now) <= invitationRenewalWindow
+}
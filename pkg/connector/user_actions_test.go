@@ -0,0 +1,33 @@
+package connector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/atlas-sdk/v20250312006/admin"
+)
+
+func TestUserActions(t *testing.T) {
+	t.Run("invitationNeedsRenewal", func(t *testing.T) {
+		now := time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+		tests := []struct {
+			name      string
+			expiresAt *time.Time
+			expected  bool
+		}{
+			{name: "no expiry date", expected: true},
+			{name: "expired", expiresAt: admin.PtrTime(now.Add(-time.Hour)), expected: true},
+			{name: "expires within the window", expiresAt: admin.PtrTime(now.Add(2 * 24 * time.Hour)), expected: true},
+			{name: "expires at the end of the window", expiresAt: admin.PtrTime(now.Add(invitationRenewalWindow)), expected: true},
+			{name: "expires after the window", expiresAt: admin.PtrTime(now.Add(20 * 24 * time.Hour)), expected: false},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expected, invitationNeedsRenewal(admin.OrganizationInvitation{ExpiresAt: tt.expiresAt}, now))
+			})
+		}
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -83,10 +85,29 @@ type atlasUserResponse interface {
 	GetOrgMembershipStatus() string
 }
 
+// atlasUserActivity is implemented by the organization and project user responses, which record when the user
+// was created and last authenticated. Team members do not carry these fields.
+type atlasUserActivity interface {
+	GetCreatedAtOk() (*time.Time, bool)
+	GetLastAuthOk() (*time.Time, bool)
+	GetMobileNumberOk() (*string, bool)
+}
+
+// atlasUserInvitation is implemented by the organization user response, which records who invited the user.
+type atlasUserInvitation interface {
+	GetInvitationCreatedAtOk() (*time.Time, bool)
+	GetInviterUsernameOk() (*string, bool)
+}
+
 type userBuilder struct {
 	resourceType    *v2.ResourceType
 	client          *admin.APIClient
 	createInviteKey bool
+	// federations holds the federation of each organization, keyed by organization ID, so that it is read once per
+	// organization rather than on every page of users.
+	federations *keyedCache[*organizationFederation]
+	// invitations holds the pending invitations of each organization, keyed by organization ID.
+	invitations *keyedCache[map[string]admin.OrganizationInvitation]
 }
 
 var _ connectorbuilder.AccountManagerV2 = (*userBuilder)(nil)
@@ -96,6 +117,19 @@ func (o *userBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
 }
 
 func newUserResource(ctx context.Context, organizationId *v2.ResourceId, user atlasUserResponse) (*v2.Resource, error) {
+	return newUserResourceWithDetails(ctx, organizationId, user, nil, nil)
+}
+
+// newUserResourceWithDetails builds a user resource and, for users who have not accepted their invitation yet,
+// records the pending organization invitation on the profile. When the organization's federation is known, the
+// user's SSO status is recorded as well.
+func newUserResourceWithDetails(
+	ctx context.Context,
+	organizationId *v2.ResourceId,
+	user atlasUserResponse,
+	invitation *admin.OrganizationInvitation,
+	federation *organizationFederation,
+) (*v2.Resource, error) {
 	userId := user.GetId()
 
 	profile := map[string]interface{}{
@@ -104,15 +138,64 @@ func newUserResource(ctx context.Context, organizationId *v2.ResourceId, user at
 		"email":      user.GetUsername(),
 		"login":      user.GetUsername(),
 		"user_id":    userId,
-		"county":     user.GetCountry(),
+		// "county" is kept for existing mappings; "country" is the correctly spelled key.
+		"county":  user.GetCountry(),
+		"country": user.GetCountry(),
 	}
 
 	userTraits := []rs.UserTraitOption{
-		rs.WithUserProfile(profile),
 		rs.WithUserLogin(user.GetUsername()),
 		rs.WithEmail(user.GetUsername(), true),
 	}
 
+	if activity, ok := user.(atlasUserActivity); ok {
+		if createdAt, ok := activity.GetCreatedAtOk(); ok {
+			profile["created_at"] = createdAt.Format(time.RFC3339)
+			userTraits = append(userTraits, rs.WithCreatedAt(*createdAt))
+		}
+		if lastAuth, ok := activity.GetLastAuthOk(); ok {
+			profile["last_auth"] = lastAuth.Format(time.RFC3339)
+			userTraits = append(userTraits, rs.WithLastLogin(*lastAuth))
+		}
+		if mobileNumber, ok := activity.GetMobileNumberOk(); ok {
+			profile["mobile_number"] = *mobileNumber
+		}
+	}
+
+	if invited, ok := user.(atlasUserInvitation); ok {
+		if invitationCreatedAt, ok := invited.GetInvitationCreatedAtOk(); ok {
+			profile["invitation_created_at"] = invitationCreatedAt.Format(time.RFC3339)
+		}
+		if inviterUsername, ok := invited.GetInviterUsernameOk(); ok {
+			profile["inviter_username"] = *inviterUsername
+		}
+	}
+
+	if invitation != nil {
+		profile["invitation_id"] = invitation.GetId()
+		profile["inviter_username"] = invitation.GetInviterUsername()
+		profile["invited_roles"] = strings.Join(invitation.GetRoles(), ", ")
+		profile["invited_team_ids"] = strings.Join(invitation.GetTeamIds(), ", ")
+		if invitation.HasCreatedAt() {
+			profile["invitation_created_at"] = invitation.GetCreatedAt().Format(time.RFC3339)
+		}
+		if invitation.HasExpiresAt() {
+			profile["invitation_expires_at"] = invitation.GetExpiresAt().Format(time.RFC3339)
+			profile["invitation_expired"] = invitation.GetExpiresAt().Before(time.Now())
+		}
+	}
+
+	if federation != nil {
+		for k, v := range federation.profile(user.GetUsername()) {
+			profile[k] = v
+		}
+
+		_, identityProvider := federation.identityProviderFor(user.GetUsername())
+		userTraits = append(userTraits, rs.WithSSOStatus(&v2.UserTrait_SSOStatus{SsoEnabled: identityProvider != nil}))
+	}
+
+	userTraits = append(userTraits, rs.WithUserProfile(profile))
+
 	switch user.GetOrgMembershipStatus() {
 	case userStatusActive:
 		userTraits = append(userTraits, rs.WithStatus(v2.UserTrait_Status_STATUS_ENABLED))
@@ -138,6 +221,50 @@ func newUserResource(ctx context.Context, organizationId *v2.ResourceId, user at
 	return resource, nil
 }
 
+// listPendingInvitations returns the organization's pending invitations keyed by the invited username.
+func listPendingInvitations(ctx context.Context, client *admin.APIClient, orgId string) (map[string]admin.OrganizationInvitation, error) {
+	invitations, resp, err := client.OrganizationsApi.ListOrganizationInvitations(ctx, orgId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization invitations: %w", parseToUHttpError(resp, err))
+	}
+
+	rv := make(map[string]admin.OrganizationInvitation, len(invitations))
+	for _, invitation := range invitations {
+		rv[invitation.GetUsername()] = invitation
+	}
+
+	return rv, nil
+}
+
+// deleteOrganizationInvitation cancels the pending invitation of a user who has not joined the organization yet.
+// Pending users cannot be removed with RemoveOrganizationUser.
+func deleteOrganizationInvitation(ctx context.Context, client *admin.APIClient, orgId string, username string) error {
+	l := ctxzap.Extract(ctx)
+
+	invitations, resp, err := client.OrganizationsApi.ListOrganizationInvitations(ctx, orgId).Username(username).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return fmt.Errorf("failed to list organization invitations: %w", parseToUHttpError(resp, err))
+	}
+
+	if len(invitations) == 0 {
+		l.Info(
+			"baton-mongodb-atlas: no pending invitation found for user",
+			zap.String("orgId", orgId),
+			zap.String("username", username),
+		)
+		return nil
+	}
+
+	for _, invitation := range invitations {
+		resp, err = client.OrganizationsApi.DeleteOrganizationInvitation(ctx, orgId, invitation.GetId()).Execute() //nolint:bodyclose // The SDK handles closing the response body
+		if err != nil {
+			return fmt.Errorf("failed to delete organization invitation: %w", parseToUHttpError(resp, err))
+		}
+	}
+
+	return nil
+}
+
 // List returns all the users from the database as resource objects.
 // Users include a UserTrait because they are the 'shape' of a standard user.
 func (o *userBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, opts rs.SyncOpAttrs) ([]*v2.Resource, *rs.SyncOpResults, error) {
@@ -162,9 +289,24 @@ func (o *userBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId,
 		return nil, nil, nil
 	}
 
+	var invitations map[string]admin.OrganizationInvitation
+	for _, user := range *users.Results {
+		if user.GetOrgMembershipStatus() == userStatusPending {
+			invitations = o.getPendingInvitations(ctx, parentResourceID.GetResource())
+			break
+		}
+	}
+
+	federation := o.getOrganizationFederation(ctx, parentResourceID.GetResource())
+
 	var resources []*v2.Resource
 	for _, user := range *users.Results {
-		resource, err := newUserResource(ctx, parentResourceID, &user)
+		var invitation *admin.OrganizationInvitation
+		if i, ok := invitations[user.GetUsername()]; ok && user.GetOrgMembershipStatus() == userStatusPending {
+			invitation = &i
+		}
+
+		resource, err := newUserResourceWithDetails(ctx, parentResourceID, &user, invitation, federation)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to create user resource: %w", err)
 		}
@@ -415,7 +557,21 @@ func (o *userBuilder) Delete(ctx context.Context, resourceId *v2.ResourceId, par
 
 	orgId := parentResourceID.Resource
 
-	resp, err := o.client.MongoDBCloudUsersApi.RemoveOrganizationUser(ctx, orgId, userId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	user, resp, err := o.client.MongoDBCloudUsersApi.GetOrganizationUser(ctx, orgId, userId).Execute() //nolint:bodyclose // The SDK handles closing the response body
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization user: %w", parseToUHttpError(resp, err))
+	}
+
+	if user.GetOrgMembershipStatus() == userStatusPending {
+		if err := deleteOrganizationInvitation(ctx, o.client, orgId, user.GetUsername()); err != nil {
+			return nil, err
+		}
+		o.invitations.invalidate(orgId)
+
+		return nil, nil
+	}
+
+	resp, err = o.client.MongoDBCloudUsersApi.RemoveOrganizationUser(ctx, orgId, userId).Execute() //nolint:bodyclose // The SDK handles closing the response body
 	if err != nil {
 		return nil, fmt.Errorf("failed to remove organization user: %w", parseToUHttpError(resp, err))
 	}
@@ -490,5 +646,47 @@ func newUserBuilder(client *admin.APIClient, createInviteKey bool) *userBuilder
 		resourceType:    userResourceType,
 		client:          client,
 		createInviteKey: createInviteKey,
+		federations:     newKeyedCache[*organizationFederation](cacheTTL),
+		invitations:     newKeyedCache[map[string]admin.OrganizationInvitation](cacheTTL),
 	}
 }
+
+// getPendingInvitations returns the organization's pending invitations keyed by the invited username, reading them
+// once per organization. Listing invitations requires the Organization User Admin role; when they cannot be read,
+// nil is returned and pending users are synced without their invitation details.
+func (o *userBuilder) getPendingInvitations(ctx context.Context, orgId string) map[string]admin.OrganizationInvitation {
+	invitations, _ := o.invitations.get(orgId, func() (map[string]admin.OrganizationInvitation, error) {
+		invitations, err := listPendingInvitations(ctx, o.client, orgId)
+		if err != nil {
+			ctxzap.Extract(ctx).Warn(
+				"baton-mongodb-atlas: failed to list organization invitations, skipping invitation details",
+				zap.String("orgId", orgId),
+				zap.Error(err),
+			)
+			return nil, nil
+		}
+		return invitations, nil
+	})
+
+	return invitations
+}
+
+// getOrganizationFederation returns the organization's federation, reading it once per organization. The federation
+// settings can only be read by organization owners; when they cannot be read, nil is returned and the SSO status is
+// left unset.
+func (o *userBuilder) getOrganizationFederation(ctx context.Context, orgId string) *organizationFederation {
+	federation, _ := o.federations.get(orgId, func() (*organizationFederation, error) {
+		federation, err := getOrganizationFederation(ctx, o.client, orgId)
+		if err != nil {
+			ctxzap.Extract(ctx).Warn(
+				"baton-mongodb-atlas: failed to read organization federation, skipping SSO status",
+				zap.String("orgId", orgId),
+				zap.Error(err),
+			)
+			return nil, nil
+		}
+		return federation, nil
+	})
+
+	return federation
+}